@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+// Supported formats of the generated config files
+const (
+	JsonConfigFormat = "json"
+	YamlConfigFormat = "yaml"
+	TomlConfigFormat = "toml"
+
+	DefaultConfigFormat = JsonConfigFormat
+)
+
+var SupportedConfigFormats = func() []string {
+	return []string{JsonConfigFormat, YamlConfigFormat, TomlConfigFormat}
+}
+
+// clearYamlStyle - remove the json style (flow collections and quoted strings) of the nodes,
+// so they are written in the plain block style of yaml
+func clearYamlStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, item := range node.Content {
+		clearYamlStyle(item)
+	}
+}
+
+// jsonNumbersToToml - convert the json numbers to int64 or float64, so the integers are not written as floats
+func jsonNumbersToToml(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = jsonNumbersToToml(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonNumbersToToml(item)
+		}
+	}
+	return value
+}
+
+// convertConfigContent - convert the json content of the config template to the format
+func convertConfigContent(content []byte, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case JsonConfigFormat:
+		return content, nil
+	case YamlConfigFormat:
+		// yaml is a superset of json, so the order of the keys is kept by the node
+		var node yaml.Node
+		err := yaml.Unmarshal(content, &node)
+		if err != nil {
+			return nil, err
+		}
+		clearYamlStyle(&node)
+
+		var b bytes.Buffer
+		encoder := yaml.NewEncoder(&b)
+		encoder.SetIndent(2)
+		err = encoder.Encode(&node)
+		if err != nil {
+			return nil, err
+		}
+		return b.Bytes(), encoder.Close()
+	case TomlConfigFormat:
+		var data map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		err := decoder.Decode(&data)
+		if err != nil {
+			return nil, err
+		}
+		return toml.Marshal(jsonNumbersToToml(data))
+	}
+
+	return nil, fmt.Errorf("not supported config format: %v", format)
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func loadConfigContent(t *testing.T, name string, format string, content []byte) map[string]interface{} {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, name+"."+format), content, 0o644)
+
+	w := &config.ViperWrapper{ConfigPath: []string{dir}, ConfigName: name}
+	err := w.Load()
+	if err != nil {
+		t.Errorf("Loading the %v config in %v format --> Expected: %v, but got %v", name, format, nil, err)
+		return nil
+	}
+	return w.Instance.AllSettings()
+}
+
+func Test_ConvertConfigContent(t *testing.T) {
+	for name, tmpl := range ExpectedConfigContentTmpl() {
+		jsonContent, err := renderConfigFile(tmpl, "test_project", JsonConfigFormat)
+		if err != nil {
+			t.Errorf("Rendering the %v config --> Expected: %v, but got %v", name, nil, err)
+			continue
+		}
+		expected := loadConfigContent(t, name, JsonConfigFormat, jsonContent)
+
+		for _, format := range []string{YamlConfigFormat, TomlConfigFormat} {
+			content, err := renderConfigFile(tmpl, "test_project", format)
+			if err != nil {
+				t.Errorf("Rendering the %v config in %v format --> Expected: %v, but got %v", name, format, nil, err)
+				continue
+			}
+
+			actual := loadConfigContent(t, name, format, content)
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("Loading the %v config in %v format --> Expected: %v, but got %v", name, format, expected, actual)
+			}
+		}
+	}
+
+	_, err := convertConfigContent([]byte(`{}`), "xml")
+	if err == nil {
+		t.Errorf("Converting to a not supported format --> Expected an error, but got %v", err)
+	}
+}
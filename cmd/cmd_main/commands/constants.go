@@ -36,10 +36,11 @@ const (
 	GitInitExecutedError = `Zhycan > Cannot execute git init command ... %v`
 	GitInitExecuted      = `Zhycan > Git repository is initialized ...`
 
-	ConfigFileIsCreated       = `Zhycan > Config File "%s" is created ...`
-	ConfigFileIsNotCreated    = `Zhycan > Config File "%s" is not created ... %v`
-	ConfigDevFileIsCreated    = `Zhycan > Config File "%s" is created for "dev" mode ...`
-	ConfigDevFileIsNotCreated = `Zhycan > Config File "%s" is not created for "dev" mode ... %v`
+	ConfigFileIsCreated        = `Zhycan > Config File "%s" is created ...`
+	ConfigFileIsNotCreated     = `Zhycan > Config File "%s" is not created ... %v`
+	ConfigDevFileIsCreated     = `Zhycan > Config File "%s" is created for "dev" mode ...`
+	ConfigDevFileIsNotCreated  = `Zhycan > Config File "%s" is not created for "dev" mode ... %v`
+	ConfigFormatIsNotSupported = `Zhycan > Config format "%s" is not supported, it must be one of %v ...`
 
 	GoModTidyExecutedError = `Zhycan > Cannot execute go mod tidy command ... %v`
 	GoModTidyExecuted      = `Zhycan > "go mod tidy" command is executed ...`
@@ -175,6 +176,12 @@ func init() {
 # File: ".gitignore" --> {{ .Time.Format .TimeFormat }} by {{.CreatorUserName}}
 # ---------------------------------------------------
 
+### Zhycan local config overrides
+configs/local/
+
+### Zhycan config snapshots
+.snapshots/
+
 ### JetBrains template
 # Covers JetBrains IDEs: IntelliJ, RubyMine, PhpStorm, AppCode, PyCharm, CLion, Android Studio, WebStorm and Rider
 # Reference: https://intellij-support.jetbrains.com/hc/en-us/articles/206544839
@@ -344,7 +351,13 @@ DerivedData/
         }
       }
     }
-  ]
+  ],
+  "admin": {
+    "enabled": false,
+    "config_path": "/_zhycan/config",
+    "logger_path": "/_zhycan/logger/levels",
+    "token": ""
+  }
 }`
 
 	protobufConfigTmpl = `{
@@ -3,6 +3,7 @@ package commands
 import (
 	"bytes"
 	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/utils"
 	"github.com/spf13/cobra"
 	"os"
 	"os/exec"
@@ -41,6 +42,7 @@ func NewInitCmd() *cobra.Command {
 		RunE: initCmdExecuteE,
 	}
 	initCmd.Flags().StringP("path", "p", ".", "The parent path to create a project")
+	initCmd.Flags().String("config-format", DefaultConfigFormat, fmt.Sprintf("The format of the config files, one of %v", SupportedConfigFormats()))
 	return initCmd
 }
 
@@ -59,6 +61,22 @@ func initCmdExecute(cmd *cobra.Command, args []string) {
 		projectPath = DefaultProjectDirectory
 	}
 
+	configFormat, err := cmd.Flags().GetString("config-format")
+	if err != nil {
+		configFormat = DefaultConfigFormat
+	}
+	configFormat = strings.ToLower(configFormat)
+	if configFormat == "yml" {
+		configFormat = YamlConfigFormat
+	}
+
+	supportedFormats := SupportedConfigFormats()
+	if !utils.ArrayContains(&supportedFormats, configFormat) {
+		fmt.Fprintln(cmd.OutOrStdout())
+		fmt.Fprintf(cmd.OutOrStdout(), ConfigFormatIsNotSupported, configFormat, supportedFormats)
+		return
+	}
+
 	expectedProjectPath := filepath.Join(projectPath, projectName)
 	if err := os.Mkdir(expectedProjectPath, os.ModePerm); err != nil {
 		fmt.Fprintln(cmd.OutOrStdout())
@@ -115,7 +133,7 @@ func initCmdExecute(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	err = createAndCopyConfigFiles(cmd, expectedProjectPath, projectName)
+	err = createAndCopyConfigFiles(cmd, expectedProjectPath, projectName, configFormat)
 	if err != nil {
 		return
 	}
@@ -611,41 +629,48 @@ func createGitIgnoreFileFile(cmd *cobra.Command, expectedProjectPath string, pro
 	return nil
 }
 
-func createAndCopyConfigFiles(cmd *cobra.Command, expectedProjectPath string, projectName string) error {
+func createAndCopyConfigFiles(cmd *cobra.Command, expectedProjectPath string, projectName string, configFormat string) error {
 	configs := ExpectedConfigFiles()
 	for _, item := range configs {
-		configFileName := fmt.Sprintf("%s_sample.json", item)
-		configDevFileName := fmt.Sprintf("%s.json", item)
+		configFileName := fmt.Sprintf("%s_sample.%s", item, configFormat)
+		configDevFileName := fmt.Sprintf("%s.%s", item, configFormat)
 		//tmplFilename := fmt.Sprintf("./templates/%s.config.gotmpl", item)
 
 		tmplContent := ExpectedConfigContentTmpl()[item]
 
 		//_ = createOneConfigFile(cmd, expectedProjectPath, configFileName, tmplFilename)
 		//_ = createOneDevConfigFile(cmd, expectedProjectPath, configDevFileName, tmplFilename, projectName)
-		_ = createOneConfigFile(cmd, expectedProjectPath, configFileName, tmplContent)
-		_ = createOneDevConfigFile(cmd, expectedProjectPath, configDevFileName, tmplContent, projectName)
+		_ = createOneConfigFile(cmd, expectedProjectPath, configFileName, tmplContent, configFormat)
+		_ = createOneDevConfigFile(cmd, expectedProjectPath, configDevFileName, tmplContent, projectName, configFormat)
 	}
 	return nil
 }
 
-func createOneConfigFile(cmd *cobra.Command, expectedProjectPath string, configFileName string, tmplFile string) error {
-	configPath := filepath.Join(expectedProjectPath, "configs", configFileName)
-	file, err := os.Create(configPath)
-	if err != nil {
-		fmt.Fprintln(cmd.OutOrStdout())
-		fmt.Fprintf(cmd.OutOrStdout(), ConfigFileIsNotCreated, configFileName, err)
-		return err
-	}
-	defer file.Close()
-
+// renderConfigFile - execute the json template of the config and convert it to the format
+func renderConfigFile(tmplFile string, projectName string, configFormat string) ([]byte, error) {
 	//temp := template.Must(template.ParseFiles(tmplFilename))
 	temp := template.Must(template.New("").Parse(tmplFile))
 	goModuleVars := struct {
 		ProjectName string
 	}{
-		ProjectName: "<project_name_here>",
+		ProjectName: projectName,
+	}
+
+	var b bytes.Buffer
+	err := temp.Execute(&b, goModuleVars)
+	if err != nil {
+		return nil, err
+	}
+	return convertConfigContent(b.Bytes(), configFormat)
+}
+
+func createOneConfigFile(cmd *cobra.Command, expectedProjectPath string, configFileName string, tmplFile string, configFormat string) error {
+	configPath := filepath.Join(expectedProjectPath, "configs", configFileName)
+
+	content, err := renderConfigFile(tmplFile, "<project_name_here>", configFormat)
+	if err == nil {
+		err = os.WriteFile(configPath, content, 0o644)
 	}
-	err = temp.Execute(file, goModuleVars)
 	if err != nil {
 		fmt.Fprintln(cmd.OutOrStdout())
 		fmt.Fprintf(cmd.OutOrStdout(), ConfigFileIsNotCreated, configFileName, err)
@@ -657,7 +682,7 @@ func createOneConfigFile(cmd *cobra.Command, expectedProjectPath string, configF
 	return nil
 }
 
-func createOneDevConfigFile(cmd *cobra.Command, expectedProjectPath string, configFileName string, tmplFile string, projectName string) error {
+func createOneDevConfigFile(cmd *cobra.Command, expectedProjectPath string, configFileName string, tmplFile string, projectName string, configFormat string) error {
 	folderPath := filepath.Join(expectedProjectPath, "configs", "dev")
 	if _, err := os.Stat(folderPath); os.IsNotExist(err) {
 		// Create a new one
@@ -671,22 +696,10 @@ func createOneDevConfigFile(cmd *cobra.Command, expectedProjectPath string, conf
 
 	configPath := filepath.Join(folderPath, configFileName)
 
-	file, err := os.Create(configPath)
-	if err != nil {
-		fmt.Fprintln(cmd.OutOrStdout())
-		fmt.Fprintf(cmd.OutOrStdout(), ConfigDevFileIsNotCreated, configFileName, err)
-		return err
-	}
-	defer file.Close()
-
-	//temp := template.Must(template.ParseFiles(tmplFilename))
-	temp := template.Must(template.New("").Parse(tmplFile))
-	goModuleVars := struct {
-		ProjectName string
-	}{
-		ProjectName: projectName,
+	content, err := renderConfigFile(tmplFile, projectName, configFormat)
+	if err == nil {
+		err = os.WriteFile(configPath, content, 0o644)
 	}
-	err = temp.Execute(file, goModuleVars)
 	if err != nil {
 		fmt.Fprintln(cmd.OutOrStdout())
 		fmt.Fprintf(cmd.OutOrStdout(), ConfigDevFileIsNotCreated, configFileName, err)
@@ -0,0 +1,110 @@
+package cache
+
+// Imports needed list
+import (
+	"encoding"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"net"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// MARK: Private Functions
+
+// encodeCacheValue - encode the value the same as the redis client writes the arguments of its commands,
+// so the caches that are not redis store the same bytes for the same values
+func encodeCacheValue(val any) ([]byte, error) {
+	switch v := val.(type) {
+	case nil:
+		return []byte{}, nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case bool:
+		if v {
+			return []byte("1"), nil
+		}
+		return []byte("0"), nil
+	case time.Time:
+		return []byte(v.Format(time.RFC3339Nano)), nil
+	case time.Duration:
+		return []byte(strconv.FormatInt(v.Nanoseconds(), 10)), nil
+	case encoding.BinaryMarshaler:
+		return v.MarshalBinary()
+	case net.IP:
+		return v, nil
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		switch rv.Elem().Kind() {
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			return encodeCacheValue(rv.Elem().Interface())
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []byte(strconv.FormatInt(rv.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []byte(strconv.FormatUint(rv.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		return []byte(strconv.FormatFloat(rv.Float(), 'f', -1, 64)), nil
+	}
+
+	return nil, fmt.Errorf("can't marshal %T (implement encoding.BinaryMarshaler)", val)
+}
+
+// decodeCacheValue - decode the stored bytes into the value the same as the redis client scans its replies
+func decodeCacheValue(data []byte, val any) error {
+	return redis.NewStringResult(string(data), nil).Scan(val)
+}
+
+// hashFields - returns the fields of the hash from the arguments of `HSet`, they are the same as the arguments of
+// the redis command: the pairs of the fields and the values, a slice of the pairs or a map
+func hashFields(val ...any) (map[string]string, error) {
+	var pairs []any
+	if len(val) == 1 {
+		switch v := val[0].(type) {
+		case map[string]any:
+			for field, item := range v {
+				pairs = append(pairs, field, item)
+			}
+		case map[string]string:
+			for field, item := range v {
+				pairs = append(pairs, field, item)
+			}
+		case []string:
+			for _, item := range v {
+				pairs = append(pairs, item)
+			}
+		case []any:
+			pairs = v
+		default:
+			return nil, fmt.Errorf("the argument of the type %T is not supported, use the pairs of the fields and the values or a map", val[0])
+		}
+	} else {
+		pairs = val
+	}
+
+	if len(pairs) == 0 || len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("the fields and the values must be in pairs")
+	}
+
+	fields := make(map[string]string, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		field, err := encodeCacheValue(pairs[i])
+		if err != nil {
+			return nil, err
+		}
+		value, err := encodeCacheValue(pairs[i+1])
+		if err != nil {
+			return nil, err
+		}
+		fields[string(field)] = string(value)
+	}
+	return fields, nil
+}
@@ -1,6 +1,13 @@
 package cache
 
-import "fmt"
+import (
+	"fmt"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNotFound - the error of the missed keys, all the caches return the error of the redis client, so the callers
+// can check the misses by `errors.Is` regardless of the cache type
+var ErrNotFound = redis.Nil
 
 // Error object
 type Error struct {
@@ -28,6 +35,11 @@ func (err *ReadError) Error() string {
 	return fmt.Sprintf("Cache Read Error: key = %s | %v", err.Key, err.Err)
 }
 
+// Unwrap - returns the cause of the error
+func (err *ReadError) Unwrap() error {
+	return err.Err
+}
+
 // NewReadError - return a new instance of ReadError
 func NewReadError(key string, err error) error {
 	return &ReadError{
@@ -48,6 +60,11 @@ func (err *WriteError) Error() string {
 	return fmt.Sprintf("Cache Write Error: key = %s / value = %v | %v", err.Key, err.Value, err.Err)
 }
 
+// Unwrap - returns the cause of the error
+func (err *WriteError) Unwrap() error {
+	return err.Err
+}
+
 // NewWriteError - return a new instance of WriteError
 func NewWriteError(key string, value any, err error) error {
 	return &WriteError{
@@ -6,6 +6,7 @@ import (
 	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/logger"
 	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"github.com/abolfazlbeh/zhycan/internal/utils"
 	"log"
 	"sync"
 	"time"
@@ -16,8 +17,10 @@ import (
 // Manager object
 type manager struct {
 	name                 string
+	configSource         config.Provider
 	lock                 sync.Mutex
 	isManagerInitialized bool
+	logger               types.Logger
 
 	caches map[string]ICache
 }
@@ -34,93 +37,133 @@ func init() {
 // init - Manager Constructor - It initializes the manager configuration params
 func (m *manager) init() {
 	m.name = "cache"
-	m.isManagerInitialized = false
 
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	prefix := config.GetManager().GetName()
+	m.initCaches()
+}
+
+// initCaches - create all caches of the connections, the lock must be held by the caller
+func (m *manager) initCaches() {
+	m.isManagerInitialized = false
+
+	prefix := m.configSource.GetName()
 	if prefix == "" {
 		return
 	}
 
 	// read configs
-	connectionsObj, err := config.GetManager().Get(m.name, "connections")
+	connections, err := config.DecodeFrom[[]string](m.configSource, m.name, "connections")
 	if err != nil {
 		return
 	}
 
 	m.caches = make(map[string]ICache)
 
-	for _, item := range connectionsObj.([]interface{}) {
-		cacheInstanceName := item.(string)
-
-		cacheType, err := config.GetManager().Get(m.name, fmt.Sprintf("%s.%s", cacheInstanceName, "type"))
+	for _, cacheInstanceName := range connections {
+		err := m.initCache(cacheInstanceName, prefix)
 		if err != nil {
 			return
 		}
+	}
 
-		withPrefix, err := config.GetManager().Get(m.name, fmt.Sprintf("%s.%s", cacheInstanceName, "add_service_prefix"))
-		if err != nil {
-			return
-		}
-		withPrefixBool := withPrefix.(bool)
+	m.isManagerInitialized = true
+}
 
-		logge, _ := logger.GetManager().GetLogger()
+// initCache - create the cache instance based on its type and config
+func (m *manager) initCache(cacheInstanceName string, prefix string) error {
+	var cfg Config
+	err := m.configSource.Unmarshal(m.name, cacheInstanceName, &cfg)
+	if err != nil {
+		return err
+	}
 
-		wrapper, err := config.GetManager().GetConfigWrapper(m.name)
-		if err == nil {
-			wrapper.RegisterChangeCallback(func() interface{} {
-				err := m.Release()
-				if err == nil {
-					m.init()
-				}
-				return nil
-			})
+	logge := logger.LoggerOrDefault(m.logger)
+
+	var tempCache ICache
+	var configKey string
+	switch cfg.Type {
+	case "redis":
+		switch cfg.RedisType {
+		case "client":
+			tempCache = &RedisClientCache{redisCache{configSource: m.configSource, logger: m.logger}}
+		case "cluster":
+			tempCache = &RedisClusterCache{redisCache{configSource: m.configSource, logger: m.logger}}
+		case "sentinel":
+			tempCache = &RedisSentinelCache{redisCache{configSource: m.configSource, logger: m.logger}}
+		default:
+			return NewError(fmt.Errorf("redis type `%v` of the cache `%v` is not supported", cfg.RedisType, cacheInstanceName))
 		}
+		configKey = fmt.Sprintf("%s.%s", cacheInstanceName, cfg.RedisType)
+	case "memcache":
+		tempCache = &MemcacheCache{configSource: m.configSource, logger: m.logger}
+		configKey = fmt.Sprintf("%s.%s", cacheInstanceName, cfg.Type)
+	case "memory":
+		tempCache = &MemoryCache{configSource: m.configSource, logger: m.logger}
+		configKey = fmt.Sprintf("%s.%s", cacheInstanceName, cfg.Type)
+	default:
+		return NewError(fmt.Errorf("type `%v` of the cache `%v` is not supported", cfg.Type, cacheInstanceName))
+	}
 
-		if cacheType == "redis" {
-			redisType, err := config.GetManager().Get(m.name, fmt.Sprintf("%s.%s", cacheInstanceName, "redis_type"))
-			if err != nil {
-				return
-			}
-
-			if redisType == "cluster" {
-				// TODO: check for cluster config
-
-			} else if redisType == "client" {
-				tempCache := &RedisClientCache{}
-				p := ""
-				if withPrefixBool == true {
-					p = prefix
-				}
-				err = tempCache.Init(m.name, fmt.Sprintf("%s.%s", cacheInstanceName, redisType), p)
-				if err != nil {
-					if logge != nil {
-						logge.Log(types.NewLogObject(types.ERROR, "Cache.Manager", types.NilObject,
-							time.Now(), "Redis Client Object is nil", err))
-					}
-					return
-				}
-
-				m.caches[cacheInstanceName] = tempCache
-			}
+	p := ""
+	if cfg.AddServicePrefix {
+		p = prefix
+	}
+	err = tempCache.Init(m.name, configKey, p)
+	if err != nil {
+		if logge != nil {
+			logge.Log(types.NewLogObject(types.ERROR, "Cache.Manager", types.NilObject,
+				time.Now(), fmt.Sprintf("Cache `%v` of the type `%v` is not initialized", cacheInstanceName, cfg.Type), err))
 		}
+		// the connections of the pool may be opened already
+		_ = tempCache.Close()
+		return err
 	}
 
-	m.isManagerInitialized = true
+	m.caches[cacheInstanceName] = tempCache
+	return nil
 }
 
 // restartOnChangeConfig - subscribe a function for when the config is changed
 func (m *manager) restartOnChangeConfig() {
 	// Config config server to reload
-	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	wrapper, err := m.configSource.GetConfigWrapper(m.name)
 	if err == nil {
-		wrapper.RegisterChangeCallback(func() interface{} {
-			if m.isManagerInitialized {
-				m.init()
+		wrapper.Subscribe("", func(event config.ChangeEvent) {
+			m.lock.Lock()
+			defer m.lock.Unlock()
+
+			if !m.isManagerInitialized {
+				return
+			}
+
+			// the list of connections is changed, so all of them must be created again
+			if event.Has("connections") {
+				err := m.release()
+				if err == nil {
+					m.initCaches()
+				}
+				return
+			}
+
+			connections, err := config.DecodeFrom[[]string](m.configSource, m.name, "connections")
+			if err != nil {
+				return
+			}
+
+			// just the caches that their config is changed are created again
+			for _, cacheInstanceName := range event.Roots() {
+				if !utils.ArrayContains(&connections, cacheInstanceName) {
+					continue
+				}
+
+				if c, ok := m.caches[cacheInstanceName]; ok {
+					_ = c.Close()
+					delete(m.caches, cacheInstanceName)
+				}
+				_ = m.initCache(cacheInstanceName, m.configSource.GetName())
 			}
-			return nil
 		})
 	} else {
 		// TODO: make some logs
@@ -129,19 +172,39 @@ func (m *manager) restartOnChangeConfig() {
 
 // MARK: Public Functions
 
+// NewManager - create an independent Cache Manager that reads its config from the source
+func NewManager(source config.Provider) *manager {
+	return NewManagerWithLogger(source, nil)
+}
+
+// NewManagerWithLogger - create an independent Cache Manager that reads its config from the source and logs through
+// the logger, the nil logger is the logger of the default manager
+func NewManagerWithLogger(source config.Provider, l types.Logger) *manager {
+	m := &manager{configSource: source, logger: l}
+	m.init()
+	m.restartOnChangeConfig()
+	return m
+}
+
 // GetManager - This function returns singleton instance of Cache Manager
 func GetManager() *manager {
 	// once used for prevent race condition and manage critical section.
 	once.Do(func() {
-		managerInstance = &manager{}
-		managerInstance.init()
-		managerInstance.restartOnChangeConfig()
+		managerInstance = NewManager(config.GetManager())
 	})
 	return managerInstance
 }
 
 // Release receiver - releases the cache instance resource
 func (m *manager) Release() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.release()
+}
+
+// release - close all caches, the lock must be held by the caller
+func (m *manager) release() error {
 	if m.caches != nil {
 		for _, cache := range m.caches {
 			err := cache.Close()
@@ -155,6 +218,9 @@ func (m *manager) Release() error {
 
 // GetCache - This function returns the instance of cache (interface of it)
 func (m *manager) GetCache(cacheName string) (ICache, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
 	if m.caches != nil {
 		if val, ok := m.caches[cacheName]; ok {
 			if val.IsInitialized() {
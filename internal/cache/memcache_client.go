@@ -0,0 +1,342 @@
+package cache
+
+// Imports needed list
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MARK: Variables
+
+var (
+	errMemcacheMiss      = errors.New("memcache: cache miss")
+	errMemcacheNotStored = errors.New("memcache: item is not stored")
+	errMemcacheConflict  = errors.New("memcache: item is changed by another client")
+	errMemcacheClosed    = errors.New("memcache: client is closed")
+)
+
+// memcacheMaxKeyLength - the longest key that the memcache servers accept
+const memcacheMaxKeyLength = 250
+
+// MARK: memcacheServerError
+
+// memcacheServerError - the `ERROR`, `CLIENT_ERROR` or `SERVER_ERROR` response, the connection is still usable after it
+type memcacheServerError struct {
+	Line string
+}
+
+// Error method - satisfying error interface
+func (err *memcacheServerError) Error() string {
+	return "memcache: " + err.Line
+}
+
+// MARK: memcacheItem
+
+// memcacheItem - the stored value with its flags, the cas is the version of the item that `gets` returns
+type memcacheItem struct {
+	value []byte
+	flags uint32
+	cas   uint64
+}
+
+// MARK: memcacheConn
+
+// memcacheConn - the connection to one server with its buffers
+type memcacheConn struct {
+	addr string
+	nc   net.Conn
+	rw   *bufio.ReadWriter
+}
+
+// MARK: memcacheClient
+
+// memcacheClient - the client of the text protocol of memcache, the keys are spread over the servers by their hash
+// and the idle connections of every server are kept for the next commands
+type memcacheClient struct {
+	servers []string
+	timeout time.Duration
+	maxIdle int
+
+	lock   sync.Mutex
+	idle   map[string][]*memcacheConn
+	closed bool
+}
+
+// newMemcacheClient - create the client, no connection is opened until the first command
+func newMemcacheClient(servers []string, timeout time.Duration, maxIdle int) *memcacheClient {
+	return &memcacheClient{
+		servers: servers,
+		timeout: timeout,
+		maxIdle: maxIdle,
+		idle:    make(map[string][]*memcacheConn),
+	}
+}
+
+// get - returns the item of the key, the cas of the item is filled too
+func (c *memcacheClient) get(ctx context.Context, key string) (*memcacheItem, error) {
+	var item *memcacheItem
+	err := c.withKey(ctx, key, func(cn *memcacheConn) error {
+		_, err := fmt.Fprintf(cn.rw, "gets %s\r\n", key)
+		if err != nil {
+			return err
+		}
+		err = cn.rw.Flush()
+		if err != nil {
+			return err
+		}
+
+		item, err = readMemcacheItem(cn.rw.Reader, key)
+		return err
+	})
+	return item, err
+}
+
+// store - run the storage command (`set`, `add` or `cas`), the expiration is in the memcache format
+func (c *memcacheClient) store(ctx context.Context, verb string, key string, item *memcacheItem, expiration int64) error {
+	return c.withKey(ctx, key, func(cn *memcacheConn) error {
+		var err error
+		if verb == "cas" {
+			_, err = fmt.Fprintf(cn.rw, "cas %s %d %d %d %d\r\n", key, item.flags, expiration, len(item.value), item.cas)
+		} else {
+			_, err = fmt.Fprintf(cn.rw, "%s %s %d %d %d\r\n", verb, key, item.flags, expiration, len(item.value))
+		}
+		if err != nil {
+			return err
+		}
+		_, err = cn.rw.Write(item.value)
+		if err != nil {
+			return err
+		}
+		_, err = cn.rw.WriteString("\r\n")
+		if err != nil {
+			return err
+		}
+		err = cn.rw.Flush()
+		if err != nil {
+			return err
+		}
+
+		line, err := readMemcacheLine(cn.rw.Reader)
+		if err != nil {
+			return err
+		}
+		switch line {
+		case "STORED":
+			return nil
+		case "NOT_STORED":
+			return errMemcacheNotStored
+		case "EXISTS":
+			return errMemcacheConflict
+		case "NOT_FOUND":
+			return errMemcacheMiss
+		}
+		return memcacheResponseError(line)
+	})
+}
+
+// ping - ask every server for its version
+func (c *memcacheClient) ping(ctx context.Context) error {
+	for _, addr := range c.servers {
+		err := c.withServer(ctx, addr, func(cn *memcacheConn) error {
+			_, err := cn.rw.WriteString("version\r\n")
+			if err != nil {
+				return err
+			}
+			err = cn.rw.Flush()
+			if err != nil {
+				return err
+			}
+
+			line, err := readMemcacheLine(cn.rw.Reader)
+			if err != nil {
+				return err
+			}
+			if !strings.HasPrefix(line, "VERSION ") {
+				return memcacheResponseError(line)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%v: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// close - close the idle connections, the busy ones are closed when they are released
+func (c *memcacheClient) close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.closed = true
+	for addr, conns := range c.idle {
+		for _, cn := range conns {
+			_ = cn.nc.Close()
+		}
+		delete(c.idle, addr)
+	}
+	return nil
+}
+
+// MARK: Private Methods
+
+// withKey - run the command on the server of the key
+func (c *memcacheClient) withKey(ctx context.Context, key string, fn func(cn *memcacheConn) error) error {
+	err := checkMemcacheKey(key)
+	if err != nil {
+		return err
+	}
+	return c.withServer(ctx, c.serverOf(key), fn)
+}
+
+// withServer - run the command on a connection of the server by the deadline of the context or the timeout
+func (c *memcacheClient) withServer(ctx context.Context, addr string, fn func(cn *memcacheConn) error) error {
+	cn, err := c.conn(ctx, addr)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	err = cn.nc.SetDeadline(deadline)
+	if err == nil {
+		err = fn(cn)
+	}
+	c.release(cn, err)
+	return err
+}
+
+// serverOf - returns the server of the key
+func (c *memcacheClient) serverOf(key string) string {
+	if len(c.servers) == 1 {
+		return c.servers[0]
+	}
+	return c.servers[crc32.ChecksumIEEE([]byte(key))%uint32(len(c.servers))]
+}
+
+// conn - returns an idle connection of the server or dial a new one
+func (c *memcacheClient) conn(ctx context.Context, addr string) (*memcacheConn, error) {
+	c.lock.Lock()
+	if c.closed {
+		c.lock.Unlock()
+		return nil, errMemcacheClosed
+	}
+	if conns := c.idle[addr]; len(conns) > 0 {
+		cn := conns[len(conns)-1]
+		c.idle[addr] = conns[:len(conns)-1]
+		c.lock.Unlock()
+		return cn, nil
+	}
+	c.lock.Unlock()
+
+	dialer := net.Dialer{Timeout: c.timeout}
+	nc, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &memcacheConn{addr: addr, nc: nc, rw: bufio.NewReadWriter(bufio.NewReader(nc), bufio.NewWriter(nc))}, nil
+}
+
+// release - keep the connection for the next commands if its response is read completely
+func (c *memcacheClient) release(cn *memcacheConn, err error) {
+	reusable := err == nil || errors.Is(err, errMemcacheMiss) || errors.Is(err, errMemcacheNotStored) ||
+		errors.Is(err, errMemcacheConflict)
+	var serverErr *memcacheServerError
+	if errors.As(err, &serverErr) {
+		reusable = true
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if reusable && !c.closed && len(c.idle[cn.addr]) < c.maxIdle {
+		c.idle[cn.addr] = append(c.idle[cn.addr], cn)
+		return
+	}
+	_ = cn.nc.Close()
+}
+
+// MARK: Private Functions
+
+// checkMemcacheKey - the keys of memcache cannot be longer than 250 bytes or have spaces and control characters
+func checkMemcacheKey(key string) error {
+	if len(key) == 0 || len(key) > memcacheMaxKeyLength {
+		return fmt.Errorf("memcache: the length of the key must be between 1 and %v", memcacheMaxKeyLength)
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] <= ' ' || key[i] == 0x7f {
+			return fmt.Errorf("memcache: the key %q has spaces or control characters", key)
+		}
+	}
+	return nil
+}
+
+// readMemcacheLine - read one line of the response without its `\r\n`
+func readMemcacheLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSuffix(line, []byte("\r\n"))), nil
+}
+
+// readMemcacheItem - read the response of `gets` which is one `VALUE` or just `END` for the missed keys
+func readMemcacheItem(r *bufio.Reader, key string) (*memcacheItem, error) {
+	line, err := readMemcacheLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if line == "END" {
+		return nil, errMemcacheMiss
+	}
+
+	// VALUE <key> <flags> <bytes> <cas unique>
+	parts := strings.Split(line, " ")
+	if len(parts) != 5 || parts[0] != "VALUE" || parts[1] != key {
+		return nil, memcacheResponseError(line)
+	}
+	flags, err1 := strconv.ParseUint(parts[2], 10, 32)
+	size, err2 := strconv.Atoi(parts[3])
+	cas, err3 := strconv.ParseUint(parts[4], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil || size < 0 {
+		return nil, fmt.Errorf("memcache: corrupt response %q", line)
+	}
+
+	data := make([]byte, size+2)
+	_, err = io.ReadFull(r, data)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasSuffix(data, []byte("\r\n")) {
+		return nil, fmt.Errorf("memcache: corrupt value of the key %q", key)
+	}
+
+	line, err = readMemcacheLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if line != "END" {
+		return nil, fmt.Errorf("memcache: corrupt response %q", line)
+	}
+
+	return &memcacheItem{value: data[:size], flags: uint32(flags), cas: cas}, nil
+}
+
+// memcacheResponseError - returns the error of the unexpected response, the errors of the server keep the connection
+func memcacheResponseError(line string) error {
+	if line == "ERROR" || strings.HasPrefix(line, "CLIENT_ERROR") || strings.HasPrefix(line, "SERVER_ERROR") {
+		return &memcacheServerError{Line: line}
+	}
+	return fmt.Errorf("memcache: unexpected response %q", line)
+}
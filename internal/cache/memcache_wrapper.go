@@ -0,0 +1,279 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"sync"
+	"time"
+)
+
+const (
+	// memcacheMaxRelativeExpiration - the longer expirations are read as unix timestamps by the memcache servers
+	memcacheMaxRelativeExpiration = 30 * 24 * time.Hour
+	// memcacheHashRetries - the times that the hash is read and written again when another client changes it
+	memcacheHashRetries = 10
+)
+
+// Mark: MemcacheCache
+
+// MemcacheCache object - the cache on the memcache servers. The hashes are kept as JSON encoded maps, and their fields
+// are changed by `cas`, so the concurrent `HSet` calls do not lose each other's fields.
+type MemcacheCache struct {
+	name         string
+	prefix       string
+	initialized  bool
+	client       *memcacheClient
+	wg           sync.WaitGroup
+	configSource config.Provider
+	logger       types.Logger
+}
+
+// MARK: Public functions
+
+// Init - Constructor: It reads the memcache configurations and checks the servers
+func (ins *MemcacheCache) Init(name string, configPrefix string, cachePrefix string) error {
+	l := logger.LoggerOrDefault(ins.logger)
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Memcache", cacheMaintenanceType, time.Now(), "Init Start", nil))
+	}
+
+	ins.wg.Add(1)
+	defer ins.wg.Done()
+
+	ins.name = name
+	ins.prefix = cachePrefix
+	ins.initialized = false
+
+	if ins.configSource == nil {
+		ins.configSource = config.GetManager()
+	}
+
+	var cfg MemcacheConfig
+	err := ins.configSource.Unmarshal(name, configPrefix, &cfg)
+	if err != nil {
+		return err
+	}
+
+	ins.client = newMemcacheClient(cfg.Addresses, time.Duration(cfg.Timeout)*time.Millisecond, cfg.MaxIdleConn)
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelFunc()
+	err = ins.Ping(ctx)
+	if err != nil {
+		return err
+	}
+
+	ins.initialized = true
+
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Memcache", cacheMaintenanceType, time.Now(), "Init End", nil))
+	}
+
+	return nil
+}
+
+// Ping - ping all the memcache servers
+func (ins *MemcacheCache) Ping(ctx context.Context) error {
+	err := ins.client.ping(ctx)
+	if err != nil {
+		return NewPingError(err)
+	}
+	return nil
+}
+
+// IsInitialized receiver - that return boolean value
+func (ins *MemcacheCache) IsInitialized() bool {
+	return ins.initialized
+}
+
+// Close - It closes the connections.
+func (ins *MemcacheCache) Close() error {
+	ins.wg.Wait()
+
+	if ins.client == nil {
+		return nil
+	}
+
+	l := logger.LoggerOrDefault(ins.logger)
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Memcache", cacheMaintenanceType, time.Now(), "Cache Connection Close", nil))
+	}
+	return ins.client.close()
+}
+
+// Get - get by key receiver
+func (ins *MemcacheCache) Get(ctx context.Context, key string, val any) error {
+	ins.wg.Wait()
+
+	item, err := ins.client.get(ctx, ins.generateKey(key))
+	if err != nil {
+		return NewReadError(key, memcacheError(err))
+	}
+
+	err = decodeCacheValue(item.value, val)
+	if err != nil {
+		return NewReadError(key, err)
+	}
+	return nil
+}
+
+// Set - set by key and expiration receiver
+func (ins *MemcacheCache) Set(ctx context.Context, key string, val any, expiration time.Duration) error {
+	ins.wg.Wait()
+
+	data, err := encodeCacheValue(val)
+	if err != nil {
+		return NewWriteError(key, val, err)
+	}
+
+	err = ins.client.store(ctx, "set", ins.generateKey(key), &memcacheItem{value: data}, memcacheExpiration(expiration))
+	if err != nil {
+		return NewWriteError(key, val, err)
+	}
+	return nil
+}
+
+// SetStruct - set the struct value by key
+func (ins *MemcacheCache) SetStruct(ctx context.Context, key string, val any, expiration time.Duration) error {
+	ins.wg.Wait()
+
+	// first marshal it
+	marshalled, err := json.Marshal(val)
+	if err != nil {
+		return NewWriteError(key, val, err)
+	}
+
+	return ins.Set(ctx, key, marshalled, expiration)
+}
+
+// GetStruct - get the struct value by key
+func (ins *MemcacheCache) GetStruct(ctx context.Context, key string, val any) error {
+	ins.wg.Wait()
+
+	var tempArr []byte
+	err := ins.Get(ctx, key, &tempArr)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(tempArr, val)
+	if err != nil {
+		return NewReadError(key, err)
+	}
+
+	return nil
+}
+
+// HSet - set the fields of the hash by key, the other fields of the hash are kept and the expiration is renewed
+func (ins *MemcacheCache) HSet(ctx context.Context, key string, expiration time.Duration, val ...any) error {
+	ins.wg.Wait()
+
+	fields, err := hashFields(val...)
+	if err != nil {
+		return NewWriteError(key, val, err)
+	}
+
+	mKey := ins.generateKey(key)
+	for i := 0; i < memcacheHashRetries; i++ {
+		item, err := ins.client.get(ctx, mKey)
+		verb := "cas"
+		hash := make(map[string]string, len(fields))
+		if errors.Is(err, errMemcacheMiss) {
+			verb = "add"
+			item = &memcacheItem{}
+		} else if err != nil {
+			return NewWriteError(key, val, err)
+		} else if err = json.Unmarshal(item.value, &hash); err != nil {
+			return NewWriteError(key, val, fmt.Errorf("the value is not a hash: %w", err))
+		}
+
+		for field, value := range fields {
+			hash[field] = value
+		}
+		item.value, err = json.Marshal(hash)
+		if err != nil {
+			return NewWriteError(key, val, err)
+		}
+
+		err = ins.client.store(ctx, verb, mKey, item, memcacheExpiration(expiration))
+		if err == nil {
+			return nil
+		}
+		// another client has changed or removed the hash in the meantime, so it's read again
+		if !errors.Is(err, errMemcacheConflict) && !errors.Is(err, errMemcacheNotStored) && !errors.Is(err, errMemcacheMiss) {
+			return NewWriteError(key, val, err)
+		}
+	}
+
+	return NewWriteError(key, val, errMemcacheConflict)
+}
+
+// HGet - get the field of the hash by key
+func (ins *MemcacheCache) HGet(ctx context.Context, key string, field string, val any) error {
+	ins.wg.Wait()
+
+	fieldKey := fmt.Sprintf("%s:%s", key, field)
+	item, err := ins.client.get(ctx, ins.generateKey(key))
+	if err != nil {
+		return NewReadError(fieldKey, memcacheError(err))
+	}
+
+	var hash map[string]string
+	err = json.Unmarshal(item.value, &hash)
+	if err != nil {
+		return NewReadError(fieldKey, fmt.Errorf("the value is not a hash: %w", err))
+	}
+
+	value, ok := hash[field]
+	if !ok {
+		return NewReadError(fieldKey, ErrNotFound)
+	}
+
+	err = decodeCacheValue([]byte(value), val)
+	if err != nil {
+		return NewReadError(fieldKey, err)
+	}
+	return nil
+}
+
+// MARK: Private Receivers
+func (ins *MemcacheCache) generateKey(key string) string {
+	newKey := key
+	if ins.prefix != "" {
+		newKey = ins.prefix + "$" + newKey
+	}
+	return newKey
+}
+
+// MARK: Private Functions
+
+// memcacheExpiration - convert the expiration to seconds, the longer ones than 30 days must be unix timestamps
+// and the zero expiration keeps the item forever like redis
+func memcacheExpiration(expiration time.Duration) int64 {
+	if expiration <= 0 {
+		return 0
+	}
+	if expiration > memcacheMaxRelativeExpiration {
+		return time.Now().Add(expiration).Unix()
+	}
+
+	// the less than a second expirations must not become zero
+	seconds := int64(expiration / time.Second)
+	if expiration%time.Second != 0 {
+		seconds++
+	}
+	return seconds
+}
+
+// memcacheError - the missed keys return the same error as the other caches
+func memcacheError(err error) error {
+	if errors.Is(err, errMemcacheMiss) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeMemcacheItem - the item of the fake memcache server
+type fakeMemcacheItem struct {
+	value      []byte
+	flags      string
+	cas        uint64
+	expiration int64
+}
+
+// fakeMemcache - the memcache server of the tests that answers `gets`, `set`, `add`, `cas` and `version`
+type fakeMemcache struct {
+	listener net.Listener
+	items    map[string]*fakeMemcacheItem
+	lastCas  uint64
+	lock     sync.Mutex
+}
+
+func newFakeMemcache(t *testing.T) *fakeMemcache {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listening --> Expected: %v, but got %v", nil, err)
+	}
+	f := &fakeMemcache{listener: listener, items: make(map[string]*fakeMemcacheItem)}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeMemcache) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		parts := strings.Fields(line)
+		if len(parts) == 0 {
+			return
+		}
+
+		f.lock.Lock()
+		switch parts[0] {
+		case "version":
+			fmt.Fprint(conn, "VERSION 1.6.0\r\n")
+		case "gets":
+			if item, ok := f.items[parts[1]]; ok {
+				fmt.Fprintf(conn, "VALUE %s %s %d %d\r\n%s\r\n", parts[1], item.flags, len(item.value), item.cas, item.value)
+			}
+			fmt.Fprint(conn, "END\r\n")
+		case "set", "add", "cas":
+			size, _ := strconv.Atoi(parts[4])
+			data := make([]byte, size+2)
+			_, _ = io.ReadFull(r, data)
+			expiration, _ := strconv.ParseInt(parts[3], 10, 64)
+
+			item, exists := f.items[parts[1]]
+			switch {
+			case parts[0] == "add" && exists:
+				fmt.Fprint(conn, "NOT_STORED\r\n")
+			case parts[0] == "cas" && !exists:
+				fmt.Fprint(conn, "NOT_FOUND\r\n")
+			case parts[0] == "cas" && strconv.FormatUint(item.cas, 10) != parts[5]:
+				fmt.Fprint(conn, "EXISTS\r\n")
+			default:
+				f.lastCas++
+				f.items[parts[1]] = &fakeMemcacheItem{value: data[:size], flags: parts[2], cas: f.lastCas, expiration: expiration}
+				fmt.Fprint(conn, "STORED\r\n")
+			}
+		default:
+			fmt.Fprint(conn, "ERROR\r\n")
+		}
+		f.lock.Unlock()
+	}
+}
+
+func (f *fakeMemcache) item(key string) *fakeMemcacheItem {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.items[key]
+}
+
+func newTestMemcache(t *testing.T, addresses ...string) (*MemcacheCache, error) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "cache"},
+		"cache": {
+			"connections": []interface{}{},
+			"main":        map[string]interface{}{"memcache": map[string]interface{}{"address": addresses, "timeout": 1000}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	c := &MemcacheCache{configSource: source}
+	return c, c.Init("cache", "main.memcache", "svc")
+}
+
+func Test_MemcacheCache(t *testing.T) {
+	server := newFakeMemcache(t)
+	c, err := newTestMemcache(t, server.listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Initializing the memcache --> Expected: %v, but got %v", nil, err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	err = c.Set(ctx, "count", 42, time.Minute)
+	if err != nil {
+		t.Fatalf("Setting the value --> Expected: %v, but got %v", nil, err)
+	}
+	item := server.item("svc$count")
+	if item == nil || string(item.value) != "42" || item.expiration != 60 {
+		t.Errorf("Stored item --> Expected: %v, but got %+v", "42 for 60 seconds", item)
+	}
+
+	var count int
+	err = c.Get(ctx, "count", &count)
+	if err != nil || count != 42 {
+		t.Errorf("Getting the value --> Expected: %v, but got %v (%v)", 42, count, err)
+	}
+
+	err = c.Get(ctx, "missing", &count)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Getting the missing key --> Expected: %v, but got %v", ErrNotFound, err)
+	}
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	err = c.SetStruct(ctx, "user", user{Name: "u1"}, 0)
+	if err != nil {
+		t.Fatalf("Setting the struct --> Expected: %v, but got %v", nil, err)
+	}
+	var u user
+	err = c.GetStruct(ctx, "user", &u)
+	if err != nil || u.Name != "u1" {
+		t.Errorf("Getting the struct --> Expected: %v, but got %v (%v)", "u1", u, err)
+	}
+
+	err = c.Set(ctx, "bad key", "v", 0)
+	if err == nil {
+		t.Errorf("Setting the key with space --> Expected an error, but got %v", err)
+	}
+}
+
+func Test_MemcacheCacheHash(t *testing.T) {
+	server := newFakeMemcache(t)
+	c, err := newTestMemcache(t, server.listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Initializing the memcache --> Expected: %v, but got %v", nil, err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := c.HSet(ctx, "session", time.Hour, fmt.Sprintf("f%d", i), i)
+			if err != nil {
+				t.Errorf("Setting the field --> Expected: %v, but got %v", nil, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 5; i++ {
+		var value int
+		err = c.HGet(ctx, "session", fmt.Sprintf("f%d", i), &value)
+		if err != nil || value != i {
+			t.Errorf("Getting the field f%d --> Expected: %v, but got %v (%v)", i, i, value, err)
+		}
+	}
+
+	var value string
+	err = c.HGet(ctx, "session", "missing", &value)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Getting the missing field --> Expected: %v, but got %v", ErrNotFound, err)
+	}
+}
+
+func Test_MemcacheExpiration(t *testing.T) {
+	if memcacheExpiration(0) != 0 || memcacheExpiration(500*time.Millisecond) != 1 || memcacheExpiration(90*time.Second) != 90 {
+		t.Errorf("Short expirations --> Expected: %v, but got %v", "0, 1 and 90 seconds", "others")
+	}
+	long := memcacheExpiration(60 * 24 * time.Hour)
+	if long < time.Now().Unix() {
+		t.Errorf("Long expiration --> Expected a unix timestamp, but got %v", long)
+	}
+}
+
+func Test_MemcacheUnavailableServer(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	c, err := newTestMemcache(t, addr)
+	if err == nil || c.IsInitialized() {
+		t.Errorf("Initializing without the server --> Expected an error, but got %v", err)
+	}
+}
@@ -0,0 +1,374 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"sync"
+	"time"
+)
+
+var (
+	// errWrongType - the same error that redis returns for the commands on the other kind of the value
+	errWrongType = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+)
+
+// Mark: memoryEntry
+
+// memoryEntry - the value of the key, it's either a plain value or the fields of the hash
+type memoryEntry struct {
+	key       string
+	value     []byte
+	hash      map[string]string
+	expiresAt time.Time
+	size      int64
+}
+
+// expired - returns whether the entry is expired at the time, the zero expiration never expires
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// Mark: MemoryCache
+
+// MemoryCache object - the cache in the memory of the process with the LRU eviction and the TTL of the keys.
+// The values are encoded the same as redis, so the code that uses the cache behaves the same with both of them.
+type MemoryCache struct {
+	name         string
+	prefix       string
+	initialized  bool
+	maxEntries   int
+	maxBytes     int64
+	bytes        int64
+	entries      map[string]*list.Element
+	order        *list.List
+	lock         sync.Mutex
+	stop         chan struct{}
+	done         chan struct{}
+	configSource config.Provider
+	logger       types.Logger
+}
+
+// MARK: Public functions
+
+// Init - Constructor: It reads the memory cache configurations and starts removing the expired entries
+func (ins *MemoryCache) Init(name string, configPrefix string, cachePrefix string) error {
+	l := logger.LoggerOrDefault(ins.logger)
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Memory", cacheMaintenanceType, time.Now(), "Init Start", nil))
+	}
+
+	ins.lock.Lock()
+	defer ins.lock.Unlock()
+
+	ins.name = name
+	ins.prefix = cachePrefix
+	ins.initialized = false
+
+	if ins.configSource == nil {
+		ins.configSource = config.GetManager()
+	}
+
+	// the memory cache works without its config block
+	cfg, err := config.DecodeOptionalFrom[MemoryConfig](ins.configSource, name, configPrefix)
+	if err != nil {
+		return err
+	}
+
+	// the cleanup of the previous init must not be left running
+	if ins.stop != nil {
+		close(ins.stop)
+	}
+
+	ins.maxEntries = cfg.MaxEntries
+	ins.maxBytes = cfg.MaxBytes
+	ins.bytes = 0
+	ins.entries = make(map[string]*list.Element)
+	ins.order = list.New()
+	ins.stop = make(chan struct{})
+	ins.done = make(chan struct{})
+	go ins.cleanup(time.Duration(cfg.CleanupInterval)*time.Millisecond, ins.stop, ins.done)
+
+	ins.initialized = true
+
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Memory", cacheMaintenanceType, time.Now(), "Init End", nil))
+	}
+
+	return nil
+}
+
+// Ping - the memory is always available
+func (ins *MemoryCache) Ping(ctx context.Context) error {
+	return nil
+}
+
+// IsInitialized receiver - that return boolean value
+func (ins *MemoryCache) IsInitialized() bool {
+	ins.lock.Lock()
+	defer ins.lock.Unlock()
+	return ins.initialized
+}
+
+// Close - It stops removing the expired entries and removes all the entries.
+func (ins *MemoryCache) Close() error {
+	ins.lock.Lock()
+	stop, done := ins.stop, ins.done
+	ins.stop = nil
+	ins.initialized = false
+	ins.entries = make(map[string]*list.Element)
+	ins.order = list.New()
+	ins.bytes = 0
+	ins.lock.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	return nil
+}
+
+// Get - get by key receiver
+func (ins *MemoryCache) Get(ctx context.Context, key string, val any) error {
+	ins.lock.Lock()
+	entry, err := ins.entry(ins.generateKey(key))
+	var data []byte
+	if err == nil {
+		if entry.hash != nil {
+			err = errWrongType
+		} else {
+			data = entry.value
+		}
+	}
+	ins.lock.Unlock()
+	if err != nil {
+		return NewReadError(key, err)
+	}
+
+	// the stored bytes are never changed, so they are decoded without the lock
+	err = decodeCacheValue(data, val)
+	if err != nil {
+		return NewReadError(key, err)
+	}
+	return nil
+}
+
+// Set - set by key and expiration receiver
+func (ins *MemoryCache) Set(ctx context.Context, key string, val any, expiration time.Duration) error {
+	data, err := encodeCacheValue(val)
+	if err != nil {
+		return NewWriteError(key, val, err)
+	}
+	// the caller may change its slice after the call
+	data = append([]byte{}, data...)
+
+	mKey := ins.generateKey(key)
+
+	ins.lock.Lock()
+	defer ins.lock.Unlock()
+
+	err = ins.put(&memoryEntry{key: mKey, value: data, expiresAt: memoryExpiration(expiration)})
+	if err != nil {
+		return NewWriteError(key, val, err)
+	}
+	return nil
+}
+
+// SetStruct - set the struct value by key
+func (ins *MemoryCache) SetStruct(ctx context.Context, key string, val any, expiration time.Duration) error {
+	// first marshal it
+	marshalled, err := json.Marshal(val)
+	if err != nil {
+		return NewWriteError(key, val, err)
+	}
+
+	return ins.Set(ctx, key, marshalled, expiration)
+}
+
+// GetStruct - get the struct value by key
+func (ins *MemoryCache) GetStruct(ctx context.Context, key string, val any) error {
+	var tempArr []byte
+	err := ins.Get(ctx, key, &tempArr)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(tempArr, val)
+	if err != nil {
+		return NewReadError(key, err)
+	}
+
+	return nil
+}
+
+// HSet - set the fields of the hash by key, the other fields of the hash are kept and the expiration is renewed
+func (ins *MemoryCache) HSet(ctx context.Context, key string, expiration time.Duration, val ...any) error {
+	fields, err := hashFields(val...)
+	if err != nil {
+		return NewWriteError(key, val, err)
+	}
+
+	mKey := ins.generateKey(key)
+
+	ins.lock.Lock()
+	defer ins.lock.Unlock()
+
+	hash := make(map[string]string, len(fields))
+	entry, err := ins.entry(mKey)
+	if err == nil {
+		if entry.hash == nil {
+			return NewWriteError(key, val, errWrongType)
+		}
+		for field, value := range entry.hash {
+			hash[field] = value
+		}
+	}
+	for field, value := range fields {
+		hash[field] = value
+	}
+
+	err = ins.put(&memoryEntry{key: mKey, hash: hash, expiresAt: memoryExpiration(expiration)})
+	if err != nil {
+		return NewWriteError(key, val, err)
+	}
+	return nil
+}
+
+// HGet - get the field of the hash by key
+func (ins *MemoryCache) HGet(ctx context.Context, key string, field string, val any) error {
+	fieldKey := fmt.Sprintf("%s:%s", key, field)
+
+	ins.lock.Lock()
+	entry, err := ins.entry(ins.generateKey(key))
+	var value string
+	if err == nil {
+		if entry.hash == nil {
+			err = errWrongType
+		} else if v, ok := entry.hash[field]; ok {
+			value = v
+		} else {
+			err = ErrNotFound
+		}
+	}
+	ins.lock.Unlock()
+	if err != nil {
+		return NewReadError(fieldKey, err)
+	}
+
+	err = decodeCacheValue([]byte(value), val)
+	if err != nil {
+		return NewReadError(fieldKey, err)
+	}
+	return nil
+}
+
+// MARK: Private Receivers
+func (ins *MemoryCache) generateKey(key string) string {
+	newKey := key
+	if ins.prefix != "" {
+		newKey = ins.prefix + "$" + newKey
+	}
+	return newKey
+}
+
+// entry - returns the entry of the key and marks it as the most recently used one, the expired entry is removed.
+// The lock must be held.
+func (ins *MemoryCache) entry(key string) (*memoryEntry, error) {
+	if !ins.initialized {
+		return nil, NewError(errors.New("memory cache is not initialized"))
+	}
+
+	element, ok := ins.entries[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if entry.expired(time.Now()) {
+		ins.remove(element)
+		return nil, ErrNotFound
+	}
+
+	ins.order.MoveToFront(element)
+	return entry, nil
+}
+
+// put - replace the entry of the key and evict the least recently used entries to fit in the bounds.
+// The lock must be held.
+func (ins *MemoryCache) put(entry *memoryEntry) error {
+	if !ins.initialized {
+		return NewError(errors.New("memory cache is not initialized"))
+	}
+
+	entry.size = int64(len(entry.key) + len(entry.value))
+	for field, value := range entry.hash {
+		entry.size += int64(len(field) + len(value))
+	}
+	if ins.maxBytes > 0 && entry.size > ins.maxBytes {
+		return fmt.Errorf("the size of the entry (%v bytes) is more than the max bytes of the cache (%v)", entry.size, ins.maxBytes)
+	}
+
+	if element, ok := ins.entries[entry.key]; ok {
+		ins.remove(element)
+	}
+	ins.entries[entry.key] = ins.order.PushFront(entry)
+	ins.bytes += entry.size
+
+	for (ins.maxEntries > 0 && ins.order.Len() > ins.maxEntries) || (ins.maxBytes > 0 && ins.bytes > ins.maxBytes) {
+		ins.remove(ins.order.Back())
+	}
+	return nil
+}
+
+// remove - remove the entry of the element. The lock must be held.
+func (ins *MemoryCache) remove(element *list.Element) {
+	entry := ins.order.Remove(element).(*memoryEntry)
+	delete(ins.entries, entry.key)
+	ins.bytes -= entry.size
+}
+
+// cleanup - remove the expired entries every interval until the cache is closed
+func (ins *MemoryCache) cleanup(interval time.Duration, stop chan struct{}, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			ins.removeExpired(now)
+		}
+	}
+}
+
+// removeExpired - remove all the expired entries at the time
+func (ins *MemoryCache) removeExpired(now time.Time) {
+	ins.lock.Lock()
+	defer ins.lock.Unlock()
+
+	for element := ins.order.Back(); element != nil; {
+		prev := element.Prev()
+		if element.Value.(*memoryEntry).expired(now) {
+			ins.remove(element)
+		}
+		element = prev
+	}
+}
+
+// MARK: Private Functions
+
+// memoryExpiration - returns the time that the entry expires, the zero expiration keeps the entry until it's evicted
+func memoryExpiration(expiration time.Duration) time.Time {
+	if expiration <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(expiration)
+}
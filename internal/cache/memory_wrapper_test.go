@@ -0,0 +1,212 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"testing"
+	"time"
+)
+
+func newTestMemoryCache(t *testing.T, memory map[string]interface{}) *MemoryCache {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "cache"},
+		"cache": {
+			"connections": []interface{}{},
+			"local":       map[string]interface{}{"type": "memory", "memory": memory},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	c := &MemoryCache{configSource: source}
+	err = c.Init("cache", "local.memory", "svc")
+	if err != nil {
+		t.Fatalf("Initializing the memory cache --> Expected: %v, but got %v", nil, err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func Test_MemoryCacheValues(t *testing.T) {
+	c := newTestMemoryCache(t, map[string]interface{}{})
+	ctx := context.Background()
+
+	if err := c.Ping(ctx); err != nil {
+		t.Errorf("Ping --> Expected: %v, but got %v", nil, err)
+	}
+
+	err := c.Set(ctx, "count", 42, 0)
+	if err != nil {
+		t.Fatalf("Setting the value --> Expected: %v, but got %v", nil, err)
+	}
+	var count int
+	var text string
+	err1 := c.Get(ctx, "count", &count)
+	err2 := c.Get(ctx, "count", &text)
+	if err1 != nil || err2 != nil || count != 42 || text != "42" {
+		t.Errorf("Getting the value --> Expected: %v, but got %v, %q (%v, %v)", 42, count, text, err1, err2)
+	}
+
+	err = c.Get(ctx, "missing", &count)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Getting the missing key --> Expected: %v, but got %v", ErrNotFound, err)
+	}
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	_ = c.SetStruct(ctx, "user", user{Name: "u1"}, time.Minute)
+	var u user
+	err = c.GetStruct(ctx, "user", &u)
+	if err != nil || u.Name != "u1" {
+		t.Errorf("Getting the struct --> Expected: %v, but got %v (%v)", "u1", u, err)
+	}
+
+	// the ttl is per key
+	_ = c.Set(ctx, "short", "v", 20*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	err = c.Get(ctx, "short", &text)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Getting the expired key --> Expected: %v, but got %v", ErrNotFound, err)
+	}
+}
+
+func Test_MemoryCacheHash(t *testing.T) {
+	c := newTestMemoryCache(t, map[string]interface{}{})
+	ctx := context.Background()
+
+	_ = c.HSet(ctx, "session", time.Minute, "user", "u1", "visits", 3)
+	_ = c.HSet(ctx, "session", time.Minute, map[string]interface{}{"visits": 4})
+
+	var user string
+	var visits int
+	err1 := c.HGet(ctx, "session", "user", &user)
+	err2 := c.HGet(ctx, "session", "visits", &visits)
+	if err1 != nil || err2 != nil || user != "u1" || visits != 4 {
+		t.Errorf("Getting the fields --> Expected: %v, but got %v %v (%v, %v)", "u1 4", user, visits, err1, err2)
+	}
+
+	err := c.HGet(ctx, "session", "missing", &user)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Getting the missing field --> Expected: %v, but got %v", ErrNotFound, err)
+	}
+
+	err = c.Get(ctx, "session", &user)
+	if !errors.Is(err, errWrongType) {
+		t.Errorf("Getting the hash as a value --> Expected: %v, but got %v", errWrongType, err)
+	}
+	_ = c.Set(ctx, "plain", "v", 0)
+	err = c.HSet(ctx, "plain", 0, "f", "v")
+	if !errors.Is(err, errWrongType) {
+		t.Errorf("Setting the field of a value --> Expected: %v, but got %v", errWrongType, err)
+	}
+	err = c.HSet(ctx, "session", 0, "odd")
+	if err == nil {
+		t.Errorf("Setting the field without value --> Expected an error, but got %v", err)
+	}
+}
+
+func Test_MemoryCacheEviction(t *testing.T) {
+	c := newTestMemoryCache(t, map[string]interface{}{"max_entries": 2})
+	ctx := context.Background()
+
+	var value string
+	_ = c.Set(ctx, "a", "1", 0)
+	_ = c.Set(ctx, "b", "2", 0)
+	// `a` is used, so `b` is the least recently used one
+	_ = c.Get(ctx, "a", &value)
+	_ = c.Set(ctx, "c", "3", 0)
+
+	if err := c.Get(ctx, "b", &value); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Getting the evicted key --> Expected: %v, but got %v", ErrNotFound, err)
+	}
+	if err := c.Get(ctx, "a", &value); err != nil || value != "1" {
+		t.Errorf("Getting the used key --> Expected: %v, but got %v (%v)", "1", value, err)
+	}
+
+	// every entry of `svc$kN` with one byte value is 7 bytes
+	c = newTestMemoryCache(t, map[string]interface{}{"max_entries": 0, "max_bytes": 14})
+	_ = c.Set(ctx, "k1", "1", 0)
+	_ = c.Set(ctx, "k2", "2", 0)
+	_ = c.Set(ctx, "k3", "3", 0)
+	if c.order.Len() != 2 || c.bytes != 14 {
+		t.Errorf("Bounded bytes --> Expected: %v, but got %v entries of %v bytes", "2 entries of 14 bytes", c.order.Len(), c.bytes)
+	}
+	if err := c.Set(ctx, "large", "0123456789", 0); err == nil {
+		t.Errorf("Setting the larger value than the cache --> Expected an error, but got %v", err)
+	}
+}
+
+func Test_MemoryCacheBackgroundExpiry(t *testing.T) {
+	c := newTestMemoryCache(t, map[string]interface{}{"cleanup_interval": 10})
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "short", "v", 5*time.Millisecond)
+	_ = c.HSet(ctx, "hash", 5*time.Millisecond, "f", "v")
+	_ = c.Set(ctx, "long", "v", time.Hour)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		c.lock.Lock()
+		n := len(c.entries)
+		c.lock.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Entries after expiry --> Expected: %v, but got %v", 1, n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func Test_ManagerMemoryCache(t *testing.T) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "svc"},
+		"cache": {
+			"connections": []interface{}{"local"},
+			"local":       map[string]interface{}{"type": "memory", "add_service_prefix": true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	m := NewManager(source)
+	defer m.Release()
+
+	c, err := m.GetCache("local")
+	if err != nil {
+		t.Fatalf("Getting the memory cache --> Expected: %v, but got %v", nil, err)
+	}
+
+	var value string
+	_ = c.Set(context.Background(), "key", "v", time.Minute)
+	err = c.Get(context.Background(), "key", &value)
+	if err != nil || value != "v" {
+		t.Errorf("Getting the value --> Expected: %v, but got %v (%v)", "v", value, err)
+	}
+}
+
+func Test_ManagerWithLogger(t *testing.T) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "svc"},
+		"cache": {
+			"connections": []interface{}{"local"},
+			"local":       map[string]interface{}{"type": "memory"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	capture := logger.NewCaptureLogger()
+	m := NewManagerWithLogger(source, capture)
+	defer m.Release()
+
+	capture.AssertLogged(t, types.DEBUG, "Cache.Memory", "Init Start")
+}
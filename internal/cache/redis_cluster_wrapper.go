@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"github.com/abolfazlbeh/zhycan/internal/logger"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// Mark: RedisClusterCache
+
+// RedisClusterCache object - the cache on the redis cluster, the keys are routed to their nodes by the client
+type RedisClusterCache struct {
+	redisCache
+}
+
+// MARK: Public functions
+
+// Init - Constructor: It reads the redis cluster configurations and initialize the connections
+func (ins *RedisClusterCache) Init(name string, configPrefix string, cachePrefix string) error {
+	l := logger.LoggerOrDefault(ins.logger)
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(), "Init Cluster Start", nil))
+	}
+
+	ins.wg.Add(1)
+	defer ins.wg.Done()
+
+	ins.start(name, cachePrefix)
+
+	var cfg RedisClusterConfig
+	err := ins.configSource.Unmarshal(name, configPrefix, &cfg)
+	if err != nil {
+		return err
+	}
+	ins.lockEnable = cfg.EnableLock
+
+	config1 := &redis.ClusterOptions{
+		Addrs:           cfg.Addresses,
+		Username:        cfg.Username,
+		Password:        cfg.Password,
+		MaxRedirects:    cfg.MaxRedirects,
+		ReadOnly:        cfg.ReadOnly,
+		RouteByLatency:  cfg.RouteByLatency,
+		RouteRandomly:   cfg.RouteRandomly,
+		MaxRetries:      cfg.MaxRetries,
+		MinRetryBackoff: time.Duration(cfg.MinRetryBackoff) * time.Millisecond,
+		MaxRetryBackoff: time.Duration(cfg.MaxRetryBackoff) * time.Millisecond,
+		DialTimeout:     time.Duration(cfg.DialTimeout) * time.Millisecond,
+		ReadTimeout:     time.Duration(cfg.ReadTimeout) * time.Millisecond,
+		WriteTimeout:    time.Duration(cfg.WriteTimeout) * time.Millisecond,
+		PoolSize:        cfg.poolSize(),
+		MinIdleConns:    cfg.MinIdleConn,
+		ConnMaxLifetime: time.Duration(cfg.MaxConnAge) * time.Millisecond,
+		PoolTimeout:     time.Duration(cfg.PoolTimeout) * time.Millisecond,
+		ConnMaxIdleTime: cfg.idleTimeout(),
+		OnConnect:       ins.onConnect(cfg.OnConnectLog),
+	}
+
+	err = ins.connect(redis.NewClusterClient(config1))
+	if err != nil {
+		return err
+	}
+
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(), "Init Cluster End", nil))
+	}
+
+	return nil
+}
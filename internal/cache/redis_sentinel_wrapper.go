@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"github.com/abolfazlbeh/zhycan/internal/logger"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// Mark: RedisSentinelCache
+
+// RedisSentinelCache object - the cache on the redis master that the sentinels watch, the client follows the
+// failovers of the master
+type RedisSentinelCache struct {
+	redisCache
+}
+
+// MARK: Public functions
+
+// Init - Constructor: It reads the redis sentinel configurations and initialize the connections.
+// With `route_by_latency` or `route_randomly` the reads go to the replicas and the writes to the master,
+// `replica_only` connects just to the replicas, so it's for the read-only caches.
+func (ins *RedisSentinelCache) Init(name string, configPrefix string, cachePrefix string) error {
+	l := logger.LoggerOrDefault(ins.logger)
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(), "Init Sentinel Start", nil))
+	}
+
+	ins.wg.Add(1)
+	defer ins.wg.Done()
+
+	ins.start(name, cachePrefix)
+
+	var cfg RedisSentinelConfig
+	err := ins.configSource.Unmarshal(name, configPrefix, &cfg)
+	if err != nil {
+		return err
+	}
+	ins.lockEnable = cfg.EnableLock
+
+	config1 := &redis.FailoverOptions{
+		MasterName:       cfg.MasterName,
+		SentinelAddrs:    cfg.SentinelAddresses,
+		SentinelUsername: cfg.SentinelUsername,
+		SentinelPassword: cfg.SentinelPassword,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		DB:               cfg.DB,
+		ReplicaOnly:      cfg.ReplicaOnly,
+		RouteByLatency:   cfg.RouteByLatency,
+		RouteRandomly:    cfg.RouteRandomly,
+		MaxRetries:       cfg.MaxRetries,
+		MinRetryBackoff:  time.Duration(cfg.MinRetryBackoff) * time.Millisecond,
+		MaxRetryBackoff:  time.Duration(cfg.MaxRetryBackoff) * time.Millisecond,
+		DialTimeout:      time.Duration(cfg.DialTimeout) * time.Millisecond,
+		ReadTimeout:      time.Duration(cfg.ReadTimeout) * time.Millisecond,
+		WriteTimeout:     time.Duration(cfg.WriteTimeout) * time.Millisecond,
+		PoolSize:         cfg.poolSize(),
+		MinIdleConns:     cfg.MinIdleConn,
+		ConnMaxLifetime:  time.Duration(cfg.MaxConnAge) * time.Millisecond,
+		PoolTimeout:      time.Duration(cfg.PoolTimeout) * time.Millisecond,
+		ConnMaxIdleTime:  cfg.idleTimeout(),
+		OnConnect:        ins.onConnect(cfg.OnConnectLog),
+	}
+
+	// routing the reads needs the master and the replicas together, which just the failover cluster client does
+	var client redis.UniversalClient
+	if cfg.RouteByLatency || cfg.RouteRandomly {
+		client = redis.NewFailoverClusterClient(config1)
+	} else {
+		client = redis.NewFailoverClient(config1)
+	}
+
+	err = ins.connect(client)
+	if err != nil {
+		return err
+	}
+
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(), "Init Sentinel End", nil))
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/abolfazlbeh/zhycan/internal/logger"
 	"github.com/abolfazlbeh/zhycan/internal/logger/types"
 	"github.com/redis/go-redis/v9"
+	"runtime"
 	"sync"
 	"time"
 )
@@ -16,133 +17,24 @@ var (
 	cacheMaintenanceType = types.NewLogType("CACHE_MAINTENANCE")
 )
 
-// Mark: RedisClientCache
-
-// RedisClientCache object
-type RedisClientCache struct {
-	name        string
-	prefix      string
-	initialized bool
-	client      *redis.Client
-	wg          sync.WaitGroup
-	lock        sync.Mutex
-	lockEnable  bool
-}
-
-// MARK: Public functions
-
-// Init - Constructor: It reads the redis client configurations and initialize the connection
-func (ins *RedisClientCache) Init(name string, configPrefix string, cachePrefix string) error {
-	l, _ := logger.GetManager().GetLogger()
-	if l != nil {
-		l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(), "Init Start", nil))
-	}
-
-	ins.wg.Add(1)
-	defer ins.wg.Done()
-
-	ins.name = name
-	ins.prefix = cachePrefix
-	ins.initialized = false
-
-	addr, err := config.GetManager().Get(name, configPrefix+".address")
-	if err != nil {
-		return err
-	}
-
-	password, err := config.GetManager().Get(name, configPrefix+".password")
-	if err != nil {
-		return err
-	}
-
-	db, err := config.GetManager().Get(name, configPrefix+".db")
-	if err != nil {
-		return err
-	}
-
-	maxRetries, err := config.GetManager().Get(name, configPrefix+".max_retries")
-	if err != nil {
-		return err
-	}
-
-	minRetryBackOff, err := config.GetManager().Get(name, configPrefix+".min_retry_backoff")
-	if err != nil {
-		return err
-	}
-
-	maxRetryBackOff, err := config.GetManager().Get(name, configPrefix+".max_retry_backoff")
-	if err != nil {
-		return err
-	}
-
-	dialTimeout, err := config.GetManager().Get(name, configPrefix+".dial_timeout")
-	if err != nil {
-		return err
-	}
-
-	readTimeout, err := config.GetManager().Get(name, configPrefix+".read_timeout")
-	if err != nil {
-		return err
-	}
-
-	writeTimeout, err := config.GetManager().Get(name, configPrefix+".write_timeout")
-	if err != nil {
-		return err
-	}
-
-	onConnectLog, err := config.GetManager().Get(name, configPrefix+".on_connect_log")
-	if err != nil {
-		return err
-	}
-
-	lockEnable, err := config.GetManager().Get(name, configPrefix+".enable_lock")
-	if err != nil {
-		return err
-	}
-	ins.lockEnable = lockEnable.(bool)
-
-	// TODO: read Others config
-
-	config1 := &redis.Options{
-		Addr:            addr.(string),
-		Password:        password.(string),
-		DB:              int(db.(float64)),
-		MaxRetries:      int(maxRetries.(float64)),
-		MinRetryBackoff: time.Duration(minRetryBackOff.(float64)) * time.Millisecond,
-		MaxRetryBackoff: time.Duration(maxRetryBackOff.(float64)) * time.Millisecond,
-		DialTimeout:     time.Duration(dialTimeout.(float64)) * time.Millisecond,
-		ReadTimeout:     time.Duration(readTimeout.(float64)) * time.Millisecond,
-		WriteTimeout:    time.Duration(writeTimeout.(float64)) * time.Millisecond,
-	}
-
-	if onConnectLog.(bool) {
-		config1.OnConnect = func(ctx context.Context, conn *redis.Conn) error {
-			// Log here
-			return nil
-		}
-	}
-
-	ins.client = redis.NewClient(config1)
-
-	ctx, cancelFunc := context.WithTimeout(context.Background(), 1*time.Second)
-	defer cancelFunc()
-	err = ins.Ping(ctx)
-	if err != nil {
-		return err
-	}
-
-	ins.initialized = true
-
-	if l != nil {
-		l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(), "Init End", nil))
-	}
-
-	return nil
+// Mark: redisCache
+
+// redisCache - the commands that are shared by all the redis types, they differ just in creating the client
+type redisCache struct {
+	name         string
+	prefix       string
+	initialized  bool
+	client       redis.UniversalClient
+	wg           sync.WaitGroup
+	lock         sync.Mutex
+	lockEnable   bool
+	configSource config.Provider
+	logger       types.Logger
 }
 
 // Ping - ping redis server
-func (ins *RedisClientCache) Ping(ctx context.Context) error {
-	l, _ := logger.GetManager().GetLogger()
+func (ins *redisCache) Ping(ctx context.Context) error {
+	l := logger.LoggerOrDefault(ins.logger)
 	if l != nil {
 		l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(), "Ping Start", nil))
 	}
@@ -160,15 +52,19 @@ func (ins *RedisClientCache) Ping(ctx context.Context) error {
 }
 
 // IsInitialized receiver - that return boolean value
-func (ins *RedisClientCache) IsInitialized() bool {
+func (ins *redisCache) IsInitialized() bool {
 	return ins.initialized
 }
 
 // Close - It closes the connection.
-func (ins *RedisClientCache) Close() error {
+func (ins *redisCache) Close() error {
 	ins.wg.Wait()
 
-	l, _ := logger.GetManager().GetLogger()
+	if ins.client == nil {
+		return nil
+	}
+
+	l := logger.LoggerOrDefault(ins.logger)
 	if l != nil {
 		l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(), "Cache Connection Close Start", nil))
 	}
@@ -184,7 +80,7 @@ func (ins *RedisClientCache) Close() error {
 }
 
 // Get - get by key receiver
-func (ins *RedisClientCache) Get(ctx context.Context, key string, val any) error {
+func (ins *redisCache) Get(ctx context.Context, key string, val any) error {
 	ins.wg.Wait()
 
 	err := ins.client.Get(ctx, ins.generateKey(key)).Scan(val)
@@ -195,7 +91,7 @@ func (ins *RedisClientCache) Get(ctx context.Context, key string, val any) error
 }
 
 // Set - set by key and expiration receiver
-func (ins *RedisClientCache) Set(ctx context.Context, key string, val any, expiration time.Duration) error {
+func (ins *redisCache) Set(ctx context.Context, key string, val any, expiration time.Duration) error {
 	ins.wg.Wait()
 
 	err := ins.client.Set(ctx, ins.generateKey(key), val, expiration).Err()
@@ -207,7 +103,7 @@ func (ins *RedisClientCache) Set(ctx context.Context, key string, val any, expir
 }
 
 // SetStruct - set the struct value by key
-func (ins *RedisClientCache) SetStruct(ctx context.Context, key string, val any, expiration time.Duration) error {
+func (ins *redisCache) SetStruct(ctx context.Context, key string, val any, expiration time.Duration) error {
 	ins.wg.Wait()
 
 	// first marshal it
@@ -217,24 +113,10 @@ func (ins *RedisClientCache) SetStruct(ctx context.Context, key string, val any,
 	}
 
 	return ins.Set(ctx, key, marshalled, expiration)
-
-	//
-	//
-	//err := ins.client.HSet(ctx, ins.generateKey(key), val).Err()
-	//if err != nil {
-	//	return NewWriteError(key, val, err)
-	//}
-	//
-	//err = ins.client.Expire(ctx, ins.generateKey(key), expiration).Err()
-	//if err != nil {
-	//	return NewWriteError(key, val, err)
-	//}
-
-	//return nil
 }
 
 // GetStruct - get the struct value by key
-func (ins *RedisClientCache) GetStruct(ctx context.Context, key string, val any) error {
+func (ins *redisCache) GetStruct(ctx context.Context, key string, val any) error {
 	ins.wg.Wait()
 
 	var tempArr []byte
@@ -251,16 +133,8 @@ func (ins *RedisClientCache) GetStruct(ctx context.Context, key string, val any)
 	return nil
 }
 
-// MARK: Private Receivers
-func (ins *RedisClientCache) generateKey(key string) string {
-	newKey := key
-	if ins.prefix != "" {
-		newKey = ins.prefix + "$" + newKey
-	}
-	return newKey
-}
-
-func (ins *RedisClientCache) HSet(ctx context.Context, key string, expiration time.Duration, val ...any) error {
+// HSet - set the fields of the hash by key and expiration
+func (ins *redisCache) HSet(ctx context.Context, key string, expiration time.Duration, val ...any) error {
 	ins.wg.Wait()
 
 	err := ins.client.HSet(ctx, key, val...).Err()
@@ -275,7 +149,8 @@ func (ins *RedisClientCache) HSet(ctx context.Context, key string, expiration ti
 	return nil
 }
 
-func (ins *RedisClientCache) HGet(ctx context.Context, key string, field string, val any) error {
+// HGet - get the field of the hash by key
+func (ins *redisCache) HGet(ctx context.Context, key string, field string, val any) error {
 	ins.wg.Wait()
 
 	cmd := ins.client.HGet(ctx, key, field)
@@ -285,3 +160,131 @@ func (ins *RedisClientCache) HGet(ctx context.Context, key string, field string,
 	}
 	return nil
 }
+
+// MARK: Private Receivers
+
+// start - reset the state of the instance before reading its config
+func (ins *redisCache) start(name string, cachePrefix string) {
+	ins.name = name
+	ins.prefix = cachePrefix
+	ins.initialized = false
+
+	if ins.configSource == nil {
+		ins.configSource = config.GetManager()
+	}
+}
+
+// connect - keep the created client and ping it, the instance is initialized if the server answers
+func (ins *redisCache) connect(client redis.UniversalClient) error {
+	ins.client = client
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelFunc()
+	err := ins.Ping(ctx)
+	if err != nil {
+		return err
+	}
+
+	ins.initialized = true
+	return nil
+}
+
+// onConnect - returns the hook that logs every new connection if it's enabled
+func (ins *redisCache) onConnect(enabled bool) func(ctx context.Context, conn *redis.Conn) error {
+	if !enabled {
+		return nil
+	}
+
+	return func(ctx context.Context, conn *redis.Conn) error {
+		l := logger.LoggerOrDefault(ins.logger)
+		if l != nil {
+			l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(),
+				fmt.Sprintf("New Connection: %v", conn.String()), ins.name))
+		}
+		return nil
+	}
+}
+
+func (ins *redisCache) generateKey(key string) string {
+	newKey := key
+	if ins.prefix != "" {
+		newKey = ins.prefix + "$" + newKey
+	}
+	return newKey
+}
+
+// MARK: RedisPoolConfig
+
+// poolSize - returns the size of the pool by the number of the cpus, zero keeps the default of the redis client
+func (c RedisPoolConfig) poolSize() int {
+	if c.PoolSizePerCpu <= 0 {
+		return 0
+	}
+	return c.PoolSizePerCpu * runtime.GOMAXPROCS(0)
+}
+
+// idleTimeout - returns the max idle time of the connections, `-1` keeps them forever
+func (c RedisPoolConfig) idleTimeout() time.Duration {
+	if c.IdleTimeout < 0 {
+		return -1
+	}
+	return time.Duration(c.IdleTimeout) * time.Millisecond
+}
+
+// Mark: RedisClientCache
+
+// RedisClientCache object
+type RedisClientCache struct {
+	redisCache
+}
+
+// MARK: Public functions
+
+// Init - Constructor: It reads the redis client configurations and initialize the connection
+func (ins *RedisClientCache) Init(name string, configPrefix string, cachePrefix string) error {
+	l := logger.LoggerOrDefault(ins.logger)
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(), "Init Start", nil))
+	}
+
+	ins.wg.Add(1)
+	defer ins.wg.Done()
+
+	ins.start(name, cachePrefix)
+
+	var cfg RedisClientConfig
+	err := ins.configSource.Unmarshal(name, configPrefix, &cfg)
+	if err != nil {
+		return err
+	}
+	ins.lockEnable = cfg.EnableLock
+
+	config1 := &redis.Options{
+		Addr:            cfg.Address,
+		Password:        cfg.Password,
+		DB:              cfg.DB,
+		MaxRetries:      cfg.MaxRetries,
+		MinRetryBackoff: time.Duration(cfg.MinRetryBackoff) * time.Millisecond,
+		MaxRetryBackoff: time.Duration(cfg.MaxRetryBackoff) * time.Millisecond,
+		DialTimeout:     time.Duration(cfg.DialTimeout) * time.Millisecond,
+		ReadTimeout:     time.Duration(cfg.ReadTimeout) * time.Millisecond,
+		WriteTimeout:    time.Duration(cfg.WriteTimeout) * time.Millisecond,
+		PoolSize:        cfg.poolSize(),
+		MinIdleConns:    cfg.MinIdleConn,
+		ConnMaxLifetime: time.Duration(cfg.MaxConnAge) * time.Millisecond,
+		PoolTimeout:     time.Duration(cfg.PoolTimeout) * time.Millisecond,
+		ConnMaxIdleTime: cfg.idleTimeout(),
+		OnConnect:       ins.onConnect(cfg.OnConnectLog),
+	}
+
+	err = ins.connect(redis.NewClient(config1))
+	if err != nil {
+		return err
+	}
+
+	if l != nil {
+		l.Log(types.NewLogObject(types.DEBUG, "Cache.Redis", cacheMaintenanceType, time.Now(), "Init End", nil))
+	}
+
+	return nil
+}
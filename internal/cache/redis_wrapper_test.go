@@ -1 +1,75 @@
 package cache
+
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"testing"
+	"time"
+)
+
+func Test_RedisConfigsDecoding(t *testing.T) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "cache"},
+		"cache": {
+			"connections": []interface{}{},
+			"main": map[string]interface{}{
+				"cluster": map[string]interface{}{
+					"addresses":         []interface{}{"127.0.0.1:7000", "127.0.0.1:7001"},
+					"route_by_latency":  true,
+					"pool_size_per_cpu": 4,
+					"idle_timeout":      -1,
+				},
+				"sentinel": map[string]interface{}{
+					"master_name":   "mymaster",
+					"min_idle_conn": 2,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	var cluster RedisClusterConfig
+	err = source.Unmarshal("cache", "main.cluster", &cluster)
+	if err != nil {
+		t.Fatalf("Decoding the cluster config --> Expected: %v, but got %v", nil, err)
+	}
+	if len(cluster.Addresses) != 2 || !cluster.RouteByLatency || cluster.PoolSizePerCpu != 4 {
+		t.Errorf("Cluster config --> Expected: %v, but got %+v", "2 addresses with the pool", cluster)
+	}
+	if cluster.idleTimeout() != -1 {
+		t.Errorf("Idle timeout --> Expected: %v, but got %v", -1, cluster.idleTimeout())
+	}
+	if (RedisPoolConfig{IdleTimeout: 5000}).idleTimeout() != 5*time.Second || (RedisPoolConfig{}).poolSize() != 0 {
+		t.Errorf("Pool settings --> Expected: %v, but got %v", "5s and the default size", "others")
+	}
+
+	// the sentinel addresses are required
+	var sentinel RedisSentinelConfig
+	err = source.Unmarshal("cache", "main.sentinel", &sentinel)
+	if err == nil {
+		t.Errorf("Decoding the sentinel config without addresses --> Expected an error, but got %v", err)
+	}
+}
+
+func Test_ManagerUnsupportedRedisType(t *testing.T) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "cache"},
+		"cache": {
+			"connections": []interface{}{"main"},
+			"main":        map[string]interface{}{"type": "redis", "redis_type": "ring"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	m := &manager{name: "cache", configSource: source, caches: make(map[string]ICache)}
+	err = m.initCache("main", "cache")
+	if err == nil {
+		t.Errorf("Creating the ring cache --> Expected an error, but got %v", err)
+	}
+	if _, err := m.GetCache("main"); err == nil {
+		t.Errorf("Getting the ring cache --> Expected an error, but got %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+package cache
+
+// Imports needed list
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+)
+
+// Schema - returns the JSON Schema of the `cache` config module
+func Schema() *config.Schema {
+	instance := config.SchemaOf(Config{})
+	instance.Properties["client"] = config.SchemaOf(RedisClientConfig{})
+	instance.Properties["cluster"] = config.SchemaOf(RedisClusterConfig{})
+	instance.Properties["sentinel"] = config.SchemaOf(RedisSentinelConfig{})
+	instance.Properties["memcache"] = config.SchemaOf(MemcacheConfig{})
+	instance.Properties["memory"] = config.SchemaOf(MemoryConfig{})
+
+	return config.ModuleSchema("cache", &config.Schema{
+		Type:     "object",
+		Required: []string{"connections"},
+		Properties: map[string]*config.Schema{
+			"connections": {Type: "array", Items: &config.Schema{Type: "string"}},
+		},
+		AdditionalProperties: instance,
+	})
+}
@@ -0,0 +1,97 @@
+package cache
+
+// Config - the structure of every cache instance in the `cache` config module
+type Config struct {
+	Type             string `json:"type" validate:"required,oneof=redis memcache memory"`
+	RedisType        string `json:"redis_type" validate:"required_if=Type redis,omitempty,oneof=client cluster sentinel"`
+	AddServicePrefix bool   `json:"add_service_prefix"`
+}
+
+// RedisPoolConfig - the pool settings that are shared by all redis types, the times are in milliseconds.
+// The pool size is `pool_size_per_cpu` * GOMAXPROCS; zero values keep the defaults of the redis client
+// and `-1` for the `idle_timeout` keeps the idle connections forever.
+type RedisPoolConfig struct {
+	PoolSizePerCpu int   `json:"pool_size_per_cpu" validate:"min=0"`
+	MinIdleConn    int   `json:"min_idle_conn" validate:"min=0"`
+	MaxConnAge     int64 `json:"max_conn_age"`
+	PoolTimeout    int64 `json:"pool_timeout" validate:"min=0"`
+	IdleTimeout    int64 `json:"idle_timeout" validate:"min=-1"`
+}
+
+// RedisClientConfig - the structure of the `client` config of the redis cache instance
+type RedisClientConfig struct {
+	Address         string `json:"address" validate:"required"`
+	Password        string `json:"password"`
+	DB              int    `json:"db" validate:"min=0"`
+	MaxRetries      int    `json:"max_retries"`
+	MinRetryBackoff int64  `json:"min_retry_backoff"`
+	MaxRetryBackoff int64  `json:"max_retry_backoff"`
+	DialTimeout     int64  `json:"dial_timeout"`
+	ReadTimeout     int64  `json:"read_timeout"`
+	WriteTimeout    int64  `json:"write_timeout"`
+	OnConnectLog    bool   `json:"on_connect_log"`
+	EnableLock      bool   `json:"enable_lock"`
+	RedisPoolConfig `json:",squash"`
+}
+
+// RedisClusterConfig - the structure of the `cluster` config of the redis cache instance.
+// The read commands go to the replicas if `read_only` is set; `route_by_latency` or `route_randomly` choose the node.
+type RedisClusterConfig struct {
+	Addresses       []string `json:"addresses" validate:"required,min=1"`
+	Username        string   `json:"username"`
+	Password        string   `json:"password"`
+	MaxRedirects    int      `json:"max_redirects" validate:"min=0"`
+	ReadOnly        bool     `json:"read_only"`
+	RouteByLatency  bool     `json:"route_by_latency"`
+	RouteRandomly   bool     `json:"route_randomly"`
+	MaxRetries      int      `json:"max_retries"`
+	MinRetryBackoff int64    `json:"min_retry_backoff"`
+	MaxRetryBackoff int64    `json:"max_retry_backoff"`
+	DialTimeout     int64    `json:"dial_timeout"`
+	ReadTimeout     int64    `json:"read_timeout"`
+	WriteTimeout    int64    `json:"write_timeout"`
+	OnConnectLog    bool     `json:"on_connect_log"`
+	EnableLock      bool     `json:"enable_lock"`
+	RedisPoolConfig `json:",squash"`
+}
+
+// RedisSentinelConfig - the structure of the `sentinel` config of the redis cache instance, the master is found by
+// the sentinels. The reads go to the replicas with `route_by_latency` or `route_randomly`, and all the commands with `replica_only`.
+type RedisSentinelConfig struct {
+	MasterName        string   `json:"master_name" validate:"required"`
+	SentinelAddresses []string `json:"sentinel_addresses" validate:"required,min=1"`
+	SentinelUsername  string   `json:"sentinel_username"`
+	SentinelPassword  string   `json:"sentinel_password"`
+	Username          string   `json:"username"`
+	Password          string   `json:"password"`
+	DB                int      `json:"db" validate:"min=0"`
+	ReplicaOnly       bool     `json:"replica_only"`
+	RouteByLatency    bool     `json:"route_by_latency"`
+	RouteRandomly     bool     `json:"route_randomly"`
+	MaxRetries        int      `json:"max_retries"`
+	MinRetryBackoff   int64    `json:"min_retry_backoff"`
+	MaxRetryBackoff   int64    `json:"max_retry_backoff"`
+	DialTimeout       int64    `json:"dial_timeout"`
+	ReadTimeout       int64    `json:"read_timeout"`
+	WriteTimeout      int64    `json:"write_timeout"`
+	OnConnectLog      bool     `json:"on_connect_log"`
+	EnableLock        bool     `json:"enable_lock"`
+	RedisPoolConfig   `json:",squash"`
+}
+
+// MemcacheConfig - the structure of the `memcache` config of the cache instance, the keys are spread over the servers
+// by their hash. The timeout is in milliseconds and the idle connections are kept per server.
+type MemcacheConfig struct {
+	Addresses   []string `json:"address" validate:"required,min=1"`
+	Timeout     int64    `json:"timeout" default:"500" validate:"min=1"`
+	MaxIdleConn int      `json:"max_idle_conn" default:"2" validate:"min=0"`
+}
+
+// MemoryConfig - the structure of the `memory` config of the cache instance, the cache is bounded by the number of
+// the entries and/or their bytes (zero is unbounded) and the least recently used entries are evicted first.
+// The expired entries are removed every `cleanup_interval` milliseconds.
+type MemoryConfig struct {
+	MaxEntries      int   `json:"max_entries" default:"10000" validate:"min=0"`
+	MaxBytes        int64 `json:"max_bytes" validate:"min=0"`
+	CleanupInterval int64 `json:"cleanup_interval" default:"1000" validate:"min=1"`
+}
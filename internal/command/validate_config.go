@@ -0,0 +1,138 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/cache"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/db"
+	"github.com/abolfazlbeh/zhycan/internal/grpc"
+	"github.com/abolfazlbeh/zhycan/internal/http"
+	"github.com/abolfazlbeh/zhycan/internal/logger"
+	"github.com/abolfazlbeh/zhycan/internal/watcher"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	ValidateConfigInitMsg        = `Zhycan > Validating the configs of "%s" mode ...`
+	ValidateConfigModuleValid    = `  [OK]      %s`
+	ValidateConfigModuleNoSchema = `  [OK]      %s (no schema, just loaded)`
+	ValidateConfigModuleSkipped  = `  [SKIPPED] %s (%s)`
+	ValidateConfigModuleInvalid  = `  [FAILED]  %s`
+	ValidateConfigErrorItem      = `      - %s`
+	ValidateConfigSchemaExported = `Zhycan > Schema of "%s" is exported to: %s`
+	ValidateConfigDefaultMode    = "dev"
+)
+
+// ConfigSchemas - returns the JSON Schema of all subsystems by the name of their config module
+func ConfigSchemas() map[string]*config.Schema {
+	return map[string]*config.Schema{
+		"db":       db.Schema(),
+		"cache":    cache.Schema(),
+		"http":     http.Schema(),
+		"protobuf": grpc.Schema(),
+		"logger":   logger.Schema(),
+		"watcher":  watcher.Schema(),
+	}
+}
+
+func NewValidateConfigCmd() *cobra.Command {
+	validateConfigCmd := &cobra.Command{
+		Use:   "validate-config [mode]",
+		Short: "Validate the config modules of the mode against their schema",
+		Long: `This command reads "configs/<mode>/base.json" and validates every module listed in it, merged from all config layers, against the schema of its subsystem.
+With "--export" the JSON Schema of all subsystems are written to the directory instead.`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+
+		Run:  validateConfigCmdExecute,
+		RunE: validateConfigCmdExecuteE,
+	}
+	validateConfigCmd.Flags().StringP("path", "p", ".", "base path of the project that contains the `configs` directory")
+	validateConfigCmd.Flags().StringP("export", "e", "", "directory to export the JSON Schema of the modules to")
+	return validateConfigCmd
+}
+
+func validateConfigCmdExecute(cmd *cobra.Command, args []string) {
+	_ = validateConfigCmdExecuteE(cmd, args)
+}
+
+func validateConfigCmdExecuteE(cmd *cobra.Command, args []string) error {
+	schemas := ConfigSchemas()
+
+	exportPath, _ := cmd.Flags().GetString("export")
+	if exportPath != "" {
+		return exportConfigSchemas(cmd, exportPath, schemas)
+	}
+
+	mode := ValidateConfigDefaultMode
+	if len(args) > 0 {
+		mode = args[0]
+	}
+	basePath, _ := cmd.Flags().GetString("path")
+
+	fmt.Fprintf(cmd.OutOrStdout(), ValidateConfigInitMsg+"\n", mode)
+	result, err := config.ValidateModules(basePath, mode, schemas)
+	if err != nil {
+		return err
+	}
+
+	errorCount := 0
+	for _, item := range result {
+		switch {
+		case item.IsSkipped:
+			fmt.Fprintf(cmd.OutOrStdout(), ValidateConfigModuleSkipped+"\n", item.Name, item.SkipReason)
+		case item.LoadingError != nil:
+			errorCount++
+			fmt.Fprintf(cmd.OutOrStdout(), ValidateConfigModuleInvalid+"\n", item.Name)
+			fmt.Fprintf(cmd.OutOrStdout(), ValidateConfigErrorItem+"\n", item.LoadingError.Error())
+		case len(item.Errors) > 0:
+			errorCount += len(item.Errors)
+			fmt.Fprintf(cmd.OutOrStdout(), ValidateConfigModuleInvalid+"\n", item.Name)
+			for _, e := range item.Errors {
+				fmt.Fprintf(cmd.OutOrStdout(), ValidateConfigErrorItem+"\n", e.Error())
+			}
+		case !item.HasSchema:
+			fmt.Fprintf(cmd.OutOrStdout(), ValidateConfigModuleNoSchema+"\n", item.Name)
+		default:
+			fmt.Fprintf(cmd.OutOrStdout(), ValidateConfigModuleValid+"\n", item.Name)
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("%d config error(s) found in %q mode", errorCount, mode)
+	}
+	return nil
+}
+
+// exportConfigSchemas - write the schema of every module to `<name>.schema.json` in the directory
+func exportConfigSchemas(cmd *cobra.Command, exportPath string, schemas map[string]*config.Schema) error {
+	err := os.MkdirAll(exportPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data, err := json.MarshalIndent(schemas[name], "", "  ")
+		if err != nil {
+			return err
+		}
+
+		file := filepath.Join(exportPath, name+".schema.json")
+		err = os.WriteFile(file, data, 0o644)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), ValidateConfigSchemaExported+"\n", name, file)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateConfigCmd(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(dir, "configs", "dev"), os.ModePerm)
+	_ = os.WriteFile(filepath.Join(dir, "configs", "dev", "base.json"), []byte(`{
+		"name": "test",
+		"modules": [{"name": "logger", "type": "local"}, {"name": "db", "type": "local"}]
+	}`), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "configs", "dev", "logger.json"), []byte(`{"type": "zap", "outputs": ["console"]}`), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "configs", "dev", "db.json"), []byte(`{
+		"connections": ["server1"],
+		"server1": {"type": "mysql", "db": "test", "username": "u", "host": "127.0.0.1"}
+	}`), 0o644)
+
+	cmd := NewValidateConfigCmd()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"dev", "--path", dir})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Errorf("Validating invalid configs --> Expected an error, but got %v", err)
+	}
+
+	out := b.String()
+	expectedLines := []string{"[OK]      logger", "[FAILED]  db", "'server1.port' in db"}
+	for _, line := range expectedLines {
+		if !strings.Contains(out, line) {
+			t.Errorf("Output of the command --> Expected to contain: %v, but got %v", line, out)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package config
+
+// Imports needed list
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MARK: Constants
+
+// DefaultChangeDebounce - the time that bursty file events are gathered before the config is reloaded
+const DefaultChangeDebounce = 200 * time.Millisecond
+
+// ChangeType - the kind of change of a key path
+type ChangeType int
+
+// Some Constants - used with ChangeType
+const (
+	KeyAdded ChangeType = iota
+	KeyModified
+	KeyRemoved
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case KeyAdded:
+		return "added"
+	case KeyModified:
+		return "modified"
+	case KeyRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// MARK: ChangeEvent
+
+// KeyChange - the change of one leaf key path with its old and new values
+type KeyChange struct {
+	Key      string      `json:"key"`
+	Type     ChangeType  `json:"type"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+// ChangeEvent - all changes of the config module that happened together
+type ChangeEvent struct {
+	Category string      `json:"category"`
+	Changes  []KeyChange `json:"changes"`
+}
+
+// ChangeListener - the function that is called with the changes of the subscribed key path
+type ChangeListener func(event ChangeEvent)
+
+// changeSubscription - the listener and the key path it is subscribed to
+type changeSubscription struct {
+	keyPath  string
+	listener ChangeListener
+}
+
+// IsEmpty - check whether the event has no change
+func (e ChangeEvent) IsEmpty() bool {
+	return len(e.Changes) == 0
+}
+
+// Has - check whether the key path or any key under it is changed
+func (e ChangeEvent) Has(keyPath string) bool {
+	for _, c := range e.Changes {
+		if matchKeyPath(keyPath, c.Key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter - returns the event with just the changes of the key path and the keys under it
+func (e ChangeEvent) Filter(keyPath string) ChangeEvent {
+	result := ChangeEvent{Category: e.Category}
+	for _, c := range e.Changes {
+		if matchKeyPath(keyPath, c.Key) {
+			result.Changes = append(result.Changes, c)
+		}
+	}
+	return result
+}
+
+// Roots - returns the sorted list of the first segment of all changed keys,
+// e.g. the name of the connections or servers that are changed
+func (e ChangeEvent) Roots() []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, c := range e.Changes {
+		root := strings.SplitN(c.Key, ".", 2)[0]
+		if !seen[root] {
+			seen[root] = true
+			result = append(result, root)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// MARK: Private Functions
+
+// matchKeyPath - check whether the key is the key path itself or is under it
+func matchKeyPath(keyPath string, key string) bool {
+	keyPath = strings.ToLower(keyPath)
+	if keyPath == "" || keyPath == key {
+		return true
+	}
+	return strings.HasPrefix(key, keyPath+".")
+}
+
+// flattenSettings - convert the nested settings to the map of leaf key paths,
+// the lists are leaves and compared as a whole
+func flattenSettings(prefix string, settings map[string]interface{}, result map[string]interface{}) {
+	for k, v := range settings {
+		key := strings.ToLower(k)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch nested := v.(type) {
+		case map[string]interface{}:
+			if len(nested) == 0 {
+				result[key] = nested
+				continue
+			}
+			flattenSettings(key, nested, result)
+		case map[interface{}]interface{}:
+			converted := make(map[string]interface{}, len(nested))
+			for nk, nv := range nested {
+				converted[fmt.Sprintf("%v", nk)] = nv
+			}
+			flattenSettings(key, converted, result)
+		default:
+			result[key] = v
+		}
+	}
+}
+
+// diffSettings - compare the old and the new settings and returns the changed leaf key paths in order
+func diffSettings(category string, previous map[string]interface{}, current map[string]interface{}) ChangeEvent {
+	oldValues := make(map[string]interface{})
+	newValues := make(map[string]interface{})
+	flattenSettings("", previous, oldValues)
+	flattenSettings("", current, newValues)
+
+	event := ChangeEvent{Category: category}
+	for k, newValue := range newValues {
+		oldValue, ok := oldValues[k]
+		if !ok {
+			event.Changes = append(event.Changes, KeyChange{Key: k, Type: KeyAdded, NewValue: newValue})
+		} else if !reflect.DeepEqual(oldValue, newValue) {
+			event.Changes = append(event.Changes, KeyChange{Key: k, Type: KeyModified, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	for k, oldValue := range oldValues {
+		if _, ok := newValues[k]; !ok {
+			event.Changes = append(event.Changes, KeyChange{Key: k, Type: KeyRemoved, OldValue: oldValue})
+		}
+	}
+
+	sort.Slice(event.Changes, func(i, j int) bool { return event.Changes[i].Key < event.Changes[j].Key })
+	return event
+}
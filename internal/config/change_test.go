@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDiffSettings(t *testing.T) {
+	previous := map[string]interface{}{
+		"connections": []interface{}{"server1", "server2"},
+		"server1":     map[string]interface{}{"host": "127.0.0.1", "port": float64(3306)},
+		"server2":     map[string]interface{}{"host": "127.0.0.2"},
+	}
+	current := map[string]interface{}{
+		"connections": []interface{}{"server1", "server2"},
+		"server1":     map[string]interface{}{"host": "127.0.0.1", "port": float64(3307)},
+		"server3":     map[string]interface{}{"host": "127.0.0.3"},
+	}
+
+	expected := []KeyChange{
+		{Key: "server1.port", Type: KeyModified, OldValue: float64(3306), NewValue: float64(3307)},
+		{Key: "server2.host", Type: KeyRemoved, OldValue: "127.0.0.2"},
+		{Key: "server3.host", Type: KeyAdded, NewValue: "127.0.0.3"},
+	}
+
+	event := diffSettings("db", previous, current)
+	if !reflect.DeepEqual(expected, event.Changes) {
+		t.Errorf("Diff of the settings --> Expected: %v, but got %v", expected, event.Changes)
+	}
+
+	expectedRoots := []string{"server1", "server2", "server3"}
+	if !reflect.DeepEqual(expectedRoots, event.Roots()) {
+		t.Errorf("Roots of the changes --> Expected: %v, but got %v", expectedRoots, event.Roots())
+	}
+
+	if !event.Has("server1") || event.Has("server") || event.Has("connections") {
+		t.Errorf("Checking the changed key paths --> got unexpected result for %v", event.Changes)
+	}
+
+	filtered := event.Filter("server1")
+	if len(filtered.Changes) != 1 || filtered.Changes[0].Key != "server1.port" {
+		t.Errorf("Filter the changes by `server1` --> Expected: %v, but got %v", expected[:1], filtered.Changes)
+	}
+}
+
+func TestWrapperSubscribe(t *testing.T) {
+	w, root := createLayeredWrapper(t)
+	w.ChangeDebounce = 100 * time.Millisecond
+	err := w.Load()
+	if err != nil {
+		t.Errorf("Loading layered wrapper --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	server1Events := make(chan ChangeEvent, 10)
+	w.Subscribe("server1", func(event ChangeEvent) {
+		server1Events <- event
+	})
+	connectionEvents := make(chan ChangeEvent, 10)
+	w.Subscribe("connections", func(event ChangeEvent) {
+		connectionEvents <- event
+	})
+	defer w.fileWatcher.Close()
+
+	// a burst of writes must end up in just one notification
+	for _, port := range []int{3308, 3309, 3310} {
+		_ = os.WriteFile(filepath.Join(root, "local", "db.json"), []byte(`{"server1": {"port": `+strconv.Itoa(port)+`}}`), 0644)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case event := <-server1Events:
+		expected := []KeyChange{{Key: "server1.port", Type: KeyModified, OldValue: float64(3307), NewValue: float64(3310)}}
+		if !reflect.DeepEqual(expected, event.Changes) {
+			t.Errorf("Changes of `server1` --> Expected: %v, but got %v", expected, event.Changes)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Changing the local layer --> Expected the listener to be called, but it is not")
+		return
+	}
+
+	select {
+	case event := <-server1Events:
+		t.Errorf("Debouncing the changes --> Expected just one event, but got another %v", event)
+	case event := <-connectionEvents:
+		t.Errorf("Listener of the unchanged key --> Expected no event, but got %v", event)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
@@ -0,0 +1,270 @@
+package config
+
+// Imports needed list
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/mitchellh/mapstructure"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MARK: Variables
+
+var (
+	structValidator     = newStructValidator()
+	decodeErrPathRegexp = regexp.MustCompile(`'([^']+)'`)
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// MARK: Private Functions
+
+// newStructValidator - create a validator that reports the field names based on the `json` tags
+func newStructValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// joinKeyPath - join the config key with the nested path of the field
+func joinKeyPath(key string, path string) string {
+	path = strings.Trim(path, ".")
+	if key == "" {
+		return path
+	}
+	if path == "" {
+		return key
+	}
+	if strings.HasPrefix(path, "[") {
+		return key + path
+	}
+	return key + "." + path
+}
+
+// applyDefaults - fill the fields of the struct that have the `default` tag
+func applyDefaults(v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldValue := v.Field(i)
+		if defaultValue, ok := field.Tag.Lookup("default"); ok {
+			err := setDefaultValue(fieldValue, defaultValue)
+			if err != nil {
+				name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+				if name == "" {
+					name = field.Name
+				}
+				return fmt.Errorf("'%s' has invalid default value %q: %v", name, defaultValue, err)
+			}
+		}
+
+		if fieldValue.Kind() == reflect.Struct {
+			err := applyDefaults(fieldValue)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// defaultsHookFunc - apply the defaults on every nested struct (e.g. items of slices) just before it is decoded
+func defaultsHookFunc() mapstructure.DecodeHookFuncValue {
+	return func(from reflect.Value, to reflect.Value) (interface{}, error) {
+		if to.Kind() == reflect.Struct && to.CanSet() && from.Kind() == reflect.Map {
+			err := applyDefaults(to)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return from.Interface(), nil
+	}
+}
+
+// setDefaultValue - parse the string value of the `default` tag based on the kind of the field
+func setDefaultValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return errors.New("just slice of strings is supported")
+		}
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if strings.TrimSpace(item) != "" {
+				items = append(items, strings.TrimSpace(item))
+			}
+		}
+		v.Set(reflect.ValueOf(items).Convert(v.Type()))
+	default:
+		return fmt.Errorf("not supported kind: %v", v.Kind())
+	}
+	return nil
+}
+
+// validateValue - validate the struct (or slice/map of structs) by the `validate` tags
+func validateValue(category string, key string, v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		err := structValidator.Struct(v.Interface())
+		if err == nil {
+			return nil
+		}
+
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+			fieldErr := validationErrors[0]
+
+			// remove the name of the root struct from the namespace
+			path := fieldErr.Namespace()
+			if idx := strings.Index(path, "."); idx >= 0 {
+				path = path[idx+1:]
+			} else {
+				path = ""
+			}
+
+			reason := fmt.Sprintf("failed on the '%s' rule", fieldErr.Tag())
+			if fieldErr.Param() != "" {
+				reason = fmt.Sprintf("failed on the '%s=%s' rule", fieldErr.Tag(), fieldErr.Param())
+			}
+			return NewDecodeErr(category, joinKeyPath(key, path), fmt.Sprintf("%s, got: %v", reason, fieldErr.Value()))
+		}
+		return NewDecodeErr(category, key, err.Error())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			err := validateValue(category, fmt.Sprintf("%s[%d]", key, i), v.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			err := validateValue(category, joinKeyPath(key, fmt.Sprintf("%v", iter.Key().Interface())), iter.Value())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeValue - decode the raw config value into the out object, then apply defaults and validation
+func decodeValue(category string, key string, input interface{}, out interface{}) error {
+	outValue := reflect.ValueOf(out)
+	if outValue.Kind() != reflect.Pointer || outValue.IsNil() {
+		return NewDecodeErr(category, key, "the output must be a non-nil pointer")
+	}
+
+	err := applyDefaults(outValue)
+	if err != nil {
+		return NewDecodeErr(category, key, err.Error())
+	}
+
+	if input != nil {
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook: mapstructure.ComposeDecodeHookFunc(
+				defaultsHookFunc(),
+				mapstructure.StringToTimeDurationHookFunc(),
+				mapstructure.StringToSliceHookFunc(","),
+			),
+			WeaklyTypedInput: true,
+			Result:           out,
+			TagName:          "json",
+		})
+		if err != nil {
+			return NewDecodeErr(category, key, err.Error())
+		}
+
+		err = decoder.Decode(input)
+		if err != nil {
+			var decodeErr *mapstructure.Error
+			if errors.As(err, &decodeErr) && len(decodeErr.Errors) > 0 {
+				matches := decodeErrPathRegexp.FindStringSubmatch(decodeErr.Errors[0])
+				if matches != nil {
+					return NewDecodeErr(category, joinKeyPath(key, matches[1]), decodeErr.Errors[0])
+				}
+				return NewDecodeErr(category, key, decodeErr.Errors[0])
+			}
+			return NewDecodeErr(category, key, err.Error())
+		}
+	}
+
+	return validateValue(category, key, outValue)
+}
+
+// MARK: Public Functions
+
+// Decode - decode the value of the key in the category of the default manager into a new object of type T.
+// An empty key decodes the whole category.
+func Decode[T any](category string, key string) (T, error) {
+	return DecodeFrom[T](GetManager(), category, key)
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testDecodeServer struct {
+	Host     string        `json:"host" validate:"required"`
+	Port     int           `json:"port" default:"3306" validate:"min=1,max=65535"`
+	Protocol string        `json:"protocol" default:"tcp" validate:"oneof=tcp udp"`
+	Timeout  time.Duration `json:"timeout" default:"2s"`
+	Options  struct {
+		Charset string `json:"charset" default:"utf8mb4"`
+	} `json:"options"`
+}
+
+func TestDecodeValue(t *testing.T) {
+	input := map[string]interface{}{
+		"host":    "127.0.0.1",
+		"port":    float64(5432),
+		"timeout": "500ms",
+	}
+
+	var actual testDecodeServer
+	err := decodeValue("db", "server1", input, &actual)
+	if err != nil {
+		t.Errorf("Decoding the value --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	expected := testDecodeServer{
+		Host:     "127.0.0.1",
+		Port:     5432,
+		Protocol: "tcp",
+		Timeout:  500 * time.Millisecond,
+	}
+	expected.Options.Charset = "utf8mb4"
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Decoding the value --> Expected: %v, but got %v", expected, actual)
+	}
+}
+
+func TestDecodeValueErrors(t *testing.T) {
+	expectedValues := []struct {
+		input       map[string]interface{}
+		expectedKey string
+	}{
+		{input: map[string]interface{}{"port": float64(3306)}, expectedKey: "server1.host"},
+		{input: map[string]interface{}{"host": "h", "port": float64(70000)}, expectedKey: "server1.port"},
+		{input: map[string]interface{}{"host": "h", "protocol": "http"}, expectedKey: "server1.protocol"},
+		{input: map[string]interface{}{"host": "h", "port": "abc"}, expectedKey: "server1.port"},
+	}
+
+	for _, item := range expectedValues {
+		var actual testDecodeServer
+		err := decodeValue("db", "server1", item.input, &actual)
+
+		decodeErr, ok := err.(*DecodeErr)
+		if !ok {
+			t.Errorf("Decoding invalid value %v --> Expected: %T, but got %v", item.input, &DecodeErr{}, err)
+			continue
+		}
+
+		if decodeErr.Category != "db" || decodeErr.Key != item.expectedKey || decodeErr.Reason == "" {
+			t.Errorf("Decoding invalid value %v --> Expected key: %v, but got %v", item.input, item.expectedKey, decodeErr)
+		}
+	}
+}
+
+func TestDecodeValueSlice(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{"host": "h1"},
+		map[string]interface{}{"port": float64(1)},
+	}
+
+	var actual []testDecodeServer
+	err := decodeValue("db", "servers", input, &actual)
+
+	expectedKey := "servers[1].host"
+	if decodeErr, ok := err.(*DecodeErr); !ok || decodeErr.Key != expectedKey {
+		t.Errorf("Decoding invalid slice --> Expected key: %v, but got %v", expectedKey, err)
+	}
+}
+
+func TestWrapperUnmarshal(t *testing.T) {
+	w, _ := createLayeredWrapper(t)
+	err := w.Load()
+	if err != nil {
+		t.Errorf("Loading layered wrapper --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	actual := struct {
+		Connections []string `json:"connections" validate:"min=1"`
+		Server1     struct {
+			Host string `json:"host"`
+			Port int    `json:"port"`
+		} `json:"server1"`
+	}{}
+	err = w.Unmarshal("", &actual)
+	if err != nil {
+		t.Errorf("Unmarshal the whole config --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	if actual.Server1.Host != "10.0.0.1" || actual.Server1.Port != 3307 || !reflect.DeepEqual(actual.Connections, []string{"server1"}) {
+		t.Errorf("Unmarshal the whole config --> got unexpected value %v", actual)
+	}
+}
+
+func TestDecodeMissingKey(t *testing.T) {
+	source, err := FromMap(map[string]map[string]interface{}{
+		"base": {"name": "decode"},
+		"db":   {"server1": map[string]interface{}{"host": "127.0.0.1"}},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	_, err = DecodeFrom[testDecodeServer](source, "db", "server2")
+	var keyErr *KeyNotExistErr
+	if !IsKeyNotExist(err) || !errors.As(err, &keyErr) || keyErr.Key != "server2" || keyErr.Category != "db" {
+		t.Errorf("Decoding the missing key --> Expected: %v, but got %v", "KeyNotExistErr of db/server2", err)
+	}
+
+	type optional struct {
+		Port    int  `json:"port" default:"3306"`
+		Enabled bool `json:"enabled"`
+	}
+	actual, err := DecodeOptionalFrom[optional](source, "db", "admin")
+	if err != nil || actual.Port != 3306 || actual.Enabled {
+		t.Errorf("Decoding the missing optional key --> Expected: %v, but got %v (%v)", optional{Port: 3306}, actual, err)
+	}
+
+	// the missing required fields still fail
+	_, err = DecodeOptionalFrom[testDecodeServer](source, "db", "server2")
+	if err == nil || IsKeyNotExist(err) {
+		t.Errorf("Decoding the missing optional key with required fields --> Expected: %v, but got %v", "DecodeErr", err)
+	}
+}
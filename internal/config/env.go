@@ -0,0 +1,166 @@
+package config
+
+// Imports needed list
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/spf13/viper"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MARK: EnvOverride
+
+// EnvOverride - the env variable that overrides the value of a key
+type EnvOverride struct {
+	Name  string      `json:"name"`
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// MARK: Private Functions
+
+// envName - convert the name or the key path to the form of the env variables, e.g. `server1.host` to `SERVER1_HOST`
+func envName(name string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(name))
+}
+
+// inferEnvValue - decode the value of a new key as json if possible (numbers, booleans, lists and objects),
+// otherwise it is a string
+func inferEnvValue(raw string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err == nil && value != nil {
+		return value
+	}
+	return raw
+}
+
+// coerceEnvValue - convert the string of the env variable to the type of the current value of the key
+func coerceEnvValue(raw string, current interface{}) (interface{}, error) {
+	switch c := current.(type) {
+	case string:
+		return raw, nil
+	case bool:
+		return strconv.ParseBool(strings.TrimSpace(raw))
+	case float64:
+		return strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	case []interface{}:
+		trimmed := strings.TrimSpace(raw)
+		if strings.HasPrefix(trimmed, "[") {
+			var result []interface{}
+			err := json.Unmarshal([]byte(trimmed), &result)
+			return result, err
+		}
+
+		// a comma separated list, the items get the type of the current items
+		result := make([]interface{}, 0)
+		if trimmed == "" {
+			return result, nil
+		}
+		for _, item := range strings.Split(trimmed, ",") {
+			item = strings.TrimSpace(item)
+			if len(c) > 0 {
+				v, err := coerceEnvValue(item, c[0])
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, v)
+			} else {
+				result = append(result, inferEnvValue(item))
+			}
+		}
+		return result, nil
+	case map[string]interface{}:
+		var result map[string]interface{}
+		err := json.Unmarshal([]byte(raw), &result)
+		return result, err
+	}
+	return inferEnvValue(raw), nil
+}
+
+// MARK: Private Methods
+
+// envPrefix - returns the prefix of the env variables of the module, e.g. `ZHYCAN_DB_`
+func (w *ViperWrapper) envPrefix() string {
+	return envName(w.ConfigEnvPrefix) + "_" + envName(w.ConfigName) + "_"
+}
+
+// applyEnvOverrides - merge the values of the env variables of the module into the instance.
+// The variable of an existing key gets its type, e.g. `ZHYCAN_DB_SERVER1_HOST` overrides `server1.host`;
+// the keys that do not exist are created with a warning and `__` separates their nested parts.
+// Without the env prefix nothing is merged, otherwise the unrelated variables like `HTTP_PROXY` leak into the modules.
+func (w *ViperWrapper) applyEnvOverrides(instance *viper.Viper) ([]EnvOverride, error) {
+	if !w.AutomaticEnv || w.ConfigEnvPrefix == "" {
+		return nil, nil
+	}
+
+	current := make(map[string]interface{})
+	flattenSettings("", instance.AllSettings(), current)
+
+	prefix := w.envPrefix()
+	keysByEnv := make(map[string]string, len(current))
+	for key := range current {
+		keysByEnv[prefix+envName(key)] = key
+	}
+
+	var names []string
+	values := make(map[string]string)
+	for _, item := range os.Environ() {
+		name, value, ok := strings.Cut(item, "=")
+		if !ok || !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+			continue
+		}
+		names = append(names, name)
+		values[name] = value
+	}
+	sort.Strings(names)
+
+	var result []EnvOverride
+	overrides := make(map[string]interface{})
+	for _, name := range names {
+		key, exist := keysByEnv[name]
+		if !exist {
+			key = strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(name, prefix), "__", "."))
+			// the key that is not in the config files may be a typo of the variable, so it's never created silently
+			log.Println(w.ConfigName, fmt.Sprintf("[WARNING] Config key `%s` does not exist and is created by env: ", key), name)
+		}
+
+		value, err := coerceEnvValue(values[name], current[key])
+		if err != nil {
+			return nil, NewEnvOverrideErr(w.ConfigName, name, key, err)
+		}
+
+		setNestedValue(overrides, key, value)
+		result = append(result, EnvOverride{Name: name, Key: key, Value: value})
+	}
+
+	if len(overrides) > 0 {
+		err := instance.MergeConfigMap(overrides)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, item := range result {
+		log.Println(w.ConfigName, fmt.Sprintf("Config key `%s` is overridden by env: ", item.Key), item.Name)
+	}
+	return result, nil
+}
+
+// MARK: Public Methods
+
+// EnvOverrides - returns the env variables that are applied on the config, the sensitive values are redacted
+func (w *ViperWrapper) EnvOverrides() []EnvOverride {
+	w.lock.Lock()
+	overrides := w.envOverrides
+	w.lock.Unlock()
+
+	result := make([]EnvOverride, len(overrides))
+	for i, item := range overrides {
+		result[i] = EnvOverride{Name: item.Name, Key: item.Key, Value: w.redactValue(item.Key, item.Value)}
+	}
+	return result
+}
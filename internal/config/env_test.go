@@ -0,0 +1,137 @@
+package config
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCoerceEnvValue(t *testing.T) {
+	tests := []struct {
+		raw      string
+		current  interface{}
+		expected interface{}
+	}{
+		{raw: "10.0.0.1", current: "127.0.0.1", expected: "10.0.0.1"},
+		{raw: "3307", current: "3306", expected: "3307"},
+		{raw: "3307", current: float64(3306), expected: float64(3307)},
+		{raw: "true", current: false, expected: true},
+		{raw: "a, b", current: []interface{}{"x"}, expected: []interface{}{"a", "b"}},
+		{raw: "1,2", current: []interface{}{float64(0)}, expected: []interface{}{float64(1), float64(2)}},
+		{raw: `["a", 1]`, current: []interface{}{}, expected: []interface{}{"a", float64(1)}},
+		{raw: "12", current: nil, expected: float64(12)},
+		{raw: "plain", current: nil, expected: "plain"},
+	}
+
+	for _, tt := range tests {
+		actual, err := coerceEnvValue(tt.raw, tt.current)
+		if err != nil || !reflect.DeepEqual(tt.expected, actual) {
+			t.Errorf("Coercing %q to the type of %v --> Expected: %v, but got %v (%v)", tt.raw, tt.current, tt.expected, actual, err)
+		}
+	}
+
+	_, err := coerceEnvValue("yes-no", true)
+	if err == nil {
+		t.Errorf("Coercing an invalid boolean --> Expected an error, but got %v", err)
+	}
+}
+
+func TestWrapperEnvOverrides(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"server1": {"host": "127.0.0.1", "port": 3306, "max_conn": 10, "ssl": false, "password": "p"}, "connections": ["server1"]}`
+	_ = os.WriteFile(filepath.Join(dir, "db.json"), []byte(content), 0o644)
+
+	t.Setenv("ZHYCAN_DB_SERVER1_HOST", "10.0.0.1")
+	t.Setenv("ZHYCAN_DB_SERVER1_MAX_CONN", "20")
+	t.Setenv("ZHYCAN_DB_SERVER1_SSL", "true")
+	t.Setenv("ZHYCAN_DB_SERVER1_PASSWORD", "env-pass")
+	t.Setenv("ZHYCAN_DB_CONNECTIONS", "server1,server2")
+	t.Setenv("ZHYCAN_DB_SERVER2__HOST", "10.0.0.2")
+	t.Setenv("ZHYCAN_CACHE_SERVER1_HOST", "other-module")
+
+	w := &ViperWrapper{ConfigPath: []string{dir}, ConfigName: "db", ConfigEnvPrefix: "ZHYCAN", AutomaticEnv: true}
+	err := w.Load()
+	if err != nil {
+		t.Errorf("Loading the wrapper --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	expected := map[string]interface{}{
+		"server1":     map[string]interface{}{"host": "10.0.0.1", "port": float64(3306), "max_conn": float64(20), "ssl": true, "password": "env-pass"},
+		"server2":     map[string]interface{}{"host": "10.0.0.2"},
+		"connections": []interface{}{"server1", "server2"},
+	}
+	if !reflect.DeepEqual(expected, w.Instance.AllSettings()) {
+		t.Errorf("Settings with env overrides --> Expected: %v, but got %v", expected, w.Instance.AllSettings())
+	}
+
+	if v, exist := w.Get("server2.host", false); !exist || v != "10.0.0.2" {
+		t.Errorf("Get the key that is created by env --> Expected: %v, but got %v", "10.0.0.2", v)
+	}
+
+	source, _ := w.GetKeySource("server1.host")
+	if !reflect.DeepEqual(KeySource{Layer: EnvLayer, Env: "ZHYCAN_DB_SERVER1_HOST"}, source) {
+		t.Errorf("Source of the overridden key --> Expected: %v, but got %v", "ZHYCAN_DB_SERVER1_HOST", source)
+	}
+
+	overrides := w.EnvOverrides()
+	if len(overrides) != 6 {
+		t.Errorf("Applied env variables --> Expected: %v, but got %v", 6, overrides)
+	}
+	for _, item := range overrides {
+		if item.Key == "server1.password" && item.Value != RedactedValue {
+			t.Errorf("Value of the sensitive env variable --> Expected: %v, but got %v", RedactedValue, item.Value)
+		}
+	}
+
+	t.Setenv("ZHYCAN_DB_SERVER1_PORT", "not-a-number")
+	err = w.Load()
+	if _, ok := err.(*EnvOverrideErr); !ok {
+		t.Errorf("Loading with an invalid env value --> Expected: %T, but got %v", &EnvOverrideErr{}, err)
+	}
+}
+
+func TestWrapperEnvOverridesWithoutPrefix(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "http.json"), []byte(`{"proxy": "none"}`), 0o644)
+
+	t.Setenv("HTTP_PROXY", "http://10.0.0.1:3128")
+	t.Setenv("HTTP_SERVERS__ADDR", ":9090")
+
+	w := &ViperWrapper{ConfigPath: []string{dir}, ConfigName: "http", AutomaticEnv: true}
+	err := w.Load()
+	if err != nil {
+		t.Fatalf("Loading the wrapper --> Expected: %v, but got %v", nil, err)
+	}
+
+	expected := map[string]interface{}{"proxy": "none"}
+	if !reflect.DeepEqual(expected, w.Instance.AllSettings()) || len(w.EnvOverrides()) != 0 {
+		t.Errorf("Settings without the env prefix --> Expected: %v, but got %v", expected, w.Instance.AllSettings())
+	}
+}
+
+func TestWrapperEnvCreatedKeyWarning(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "db.json"), []byte(`{"server1": {"host": "127.0.0.1"}}`), 0o644)
+
+	// a typo of `ZHYCAN_DB_SERVER1_HOST`
+	t.Setenv("ZHYCAN_DB_SERVER2_HOST", "10.0.0.2")
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	w := &ViperWrapper{ConfigPath: []string{dir}, ConfigName: "db", ConfigEnvPrefix: "ZHYCAN", AutomaticEnv: true}
+	err := w.Load()
+	if err != nil {
+		t.Fatalf("Loading the wrapper --> Expected: %v, but got %v", nil, err)
+	}
+
+	if !strings.Contains(output.String(), "[WARNING] Config key `server2_host` does not exist") {
+		t.Errorf("Logs of the created key --> Expected a warning of: %v, but got %v", "server2_host", output.String())
+	}
+}
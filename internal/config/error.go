@@ -2,6 +2,7 @@ package config
 
 // Imports needed list
 import (
+	"errors"
 	"fmt"
 )
 
@@ -26,6 +27,12 @@ func NewKeyNotExistErr(key, category string, err error) error {
 	}
 }
 
+// IsKeyNotExist - check whether the error is KeyNotExistErr
+func IsKeyNotExist(err error) bool {
+	var keyErr *KeyNotExistErr
+	return errors.As(err, &keyErr)
+}
+
 // CategoryNotExistErr Error
 type CategoryNotExistErr struct {
 	Key interface{}
@@ -78,3 +85,130 @@ func (err *RemoteResponseErr) Error() string {
 func NewRemoteResponseErr(err error) error {
 	return &RemoteResponseErr{Err: err}
 }
+
+// DecodeErr Error
+type DecodeErr struct {
+	Category string
+	Key      string
+	Reason   string
+}
+
+// Error method - satisfying error interface
+func (err *DecodeErr) Error() string {
+	return fmt.Sprintf("Cannot decode the key: '%v' in %v | %v", err.Key, err.Category, err.Reason)
+}
+
+// NewDecodeErr - return a new instance of DecodeErr
+func NewDecodeErr(category string, key string, reason string) error {
+	return &DecodeErr{
+		Category: category,
+		Key:      key,
+		Reason:   RedactSecrets(reason),
+	}
+}
+
+// SecretResolveErr Error
+type SecretResolveErr struct {
+	Category string
+	Key      string
+	Err      error
+}
+
+// Error method - satisfying error interface
+func (err *SecretResolveErr) Error() string {
+	return fmt.Sprintf("Cannot resolve the secret of the key: '%v' in %v | %v", err.Key, err.Category, err.Err)
+}
+
+// NewSecretResolveErr - return a new instance of SecretResolveErr
+func NewSecretResolveErr(category string, key string, err error) error {
+	return &SecretResolveErr{
+		Category: category,
+		Key:      key,
+		Err:      err,
+	}
+}
+
+// SchemaErr Error
+type SchemaErr struct {
+	Category string
+	Key      string
+	Reason   string
+}
+
+// Error method - satisfying error interface
+func (err *SchemaErr) Error() string {
+	if err.Key == "" {
+		return fmt.Sprintf("The config of %v does not match the schema | %v", err.Category, err.Reason)
+	}
+	return fmt.Sprintf("The key: '%v' in %v does not match the schema | %v", err.Key, err.Category, err.Reason)
+}
+
+// NewSchemaErr - return a new instance of SchemaErr
+func NewSchemaErr(category string, key string, reason string) error {
+	return &SchemaErr{
+		Category: category,
+		Key:      key,
+		Reason:   RedactSecrets(reason),
+	}
+}
+
+// UpdateErr Error
+type UpdateErr struct {
+	Category string
+	Err      error
+}
+
+// Error method - satisfying error interface
+func (err *UpdateErr) Error() string {
+	return fmt.Sprintf("Cannot update the config of %v | %v", err.Category, err.Err)
+}
+
+// NewUpdateErr - return a new instance of UpdateErr
+func NewUpdateErr(category string, err error) error {
+	return &UpdateErr{
+		Category: category,
+		Err:      err,
+	}
+}
+
+// SnapshotNotExistErr Error
+type SnapshotNotExistErr struct {
+	Category string
+	Version  int
+}
+
+// Error method - satisfying error interface
+func (err *SnapshotNotExistErr) Error() string {
+	return fmt.Sprintf("The snapshot version: '%v' of %v does not exist", err.Version, err.Category)
+}
+
+// NewSnapshotNotExistErr - return a new instance of SnapshotNotExistErr
+func NewSnapshotNotExistErr(category string, version int) error {
+	return &SnapshotNotExistErr{
+		Category: category,
+		Version:  version,
+	}
+}
+
+// EnvOverrideErr Error
+type EnvOverrideErr struct {
+	Category string
+	Name     string
+	Key      string
+	Err      error
+}
+
+// Error method - satisfying error interface
+func (err *EnvOverrideErr) Error() string {
+	return fmt.Sprintf("Cannot override the key: '%v' in %v by the env variable: %v | %v", err.Key, err.Category, err.Name, err.Err)
+}
+
+// NewEnvOverrideErr - return a new instance of EnvOverrideErr
+func NewEnvOverrideErr(category string, name string, key string, err error) error {
+	return &EnvOverrideErr{
+		Category: category,
+		Name:     name,
+		Key:      key,
+		Err:      err,
+	}
+}
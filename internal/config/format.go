@@ -0,0 +1,106 @@
+package config
+
+// Imports needed list
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// MARK: Private Functions
+
+// normalizeValue - convert the value decoded from any format (json, yaml, toml, hcl) to the JSON model:
+// numbers are float64, lists are []interface{} and objects are map[string]interface{}.
+// So a module has the same value types whatever the format of its file is.
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, string, bool, float64:
+		return v
+	case map[string]interface{}:
+		return normalizeSettings(v)
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			result[fmt.Sprintf("%v", k)] = normalizeValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = normalizeValue(item)
+		}
+		return result
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32:
+		return rv.Float()
+	case reflect.Slice, reflect.Array:
+		// e.g. the array of tables in toml is decoded as []map[string]interface{}
+		result := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			result[i] = normalizeValue(rv.Index(i).Interface())
+		}
+		return result
+	case reflect.Map:
+		result := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			result[fmt.Sprintf("%v", iter.Key().Interface())] = normalizeValue(iter.Value().Interface())
+		}
+		return result
+	}
+	return value
+}
+
+// normalizeSettings - normalize all values of the settings, see normalizeValue
+func normalizeSettings(settings map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		result[k] = normalizeValue(v)
+	}
+	return result
+}
+
+// flattenHclBlocks - the hcl decoder returns every block (e.g. `server1 { ... }`) as a list with one object,
+// so the single blocks are converted to the object itself like the other formats
+func flattenHclBlocks(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []map[string]interface{}:
+		if len(v) == 1 {
+			return flattenHclBlocks(v[0])
+		}
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = flattenHclBlocks(item)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			result[k] = flattenHclBlocks(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = flattenHclBlocks(item)
+		}
+		return result
+	}
+	return value
+}
+
+// normalizeFileSettings - normalize the settings that are read from the config file based on its format
+func normalizeFileSettings(file string, settings map[string]interface{}) map[string]interface{} {
+	if strings.ToLower(strings.TrimPrefix(filepath.Ext(file), ".")) == "hcl" {
+		settings = flattenHclBlocks(settings).(map[string]interface{})
+	}
+	return normalizeSettings(settings)
+}
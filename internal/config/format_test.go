@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWrapperLoadFormats(t *testing.T) {
+	contents := map[string]string{
+		"json": `{
+  "connections": ["server1"],
+  "server1": {"host": "127.0.0.1", "port": "3306", "max_conn": 10, "ratio": 0.5, "options": {"charset": "utf8"}},
+  "servers": [{"name": "s1", "addr": ":3000"}, {"name": "s2", "addr": ":3001"}]
+}`,
+		"yaml": `
+connections:
+  - server1
+server1:
+  host: 127.0.0.1
+  port: "3306"
+  max_conn: 10
+  ratio: 0.5
+  options:
+    charset: utf8
+servers:
+  - name: s1
+    addr: ":3000"
+  - name: s2
+    addr: ":3001"
+`,
+		"toml": `
+connections = ["server1"]
+
+[server1]
+host = "127.0.0.1"
+port = "3306"
+max_conn = 10
+ratio = 0.5
+
+[server1.options]
+charset = "utf8"
+
+[[servers]]
+name = "s1"
+addr = ":3000"
+
+[[servers]]
+name = "s2"
+addr = ":3001"
+`,
+		"hcl": `
+connections = ["server1"]
+
+server1 {
+  host = "127.0.0.1"
+  port = "3306"
+  max_conn = 10
+  ratio = 0.5
+
+  options {
+    charset = "utf8"
+  }
+}
+
+servers = [
+  { name = "s1", addr = ":3000" },
+  { name = "s2", addr = ":3001" },
+]
+`,
+	}
+
+	settings := make(map[string]map[string]interface{})
+	for format, content := range contents {
+		dir := t.TempDir()
+		_ = os.WriteFile(filepath.Join(dir, "db."+format), []byte(content), 0o644)
+
+		w := &ViperWrapper{ConfigPath: []string{dir}, ConfigName: "db"}
+		err := w.Load()
+		if err != nil {
+			t.Errorf("Loading the config in %v format --> Expected: %v, but got %v", format, nil, err)
+			continue
+		}
+		settings[format] = w.Instance.AllSettings()
+	}
+
+	for _, format := range []string{"yaml", "toml", "hcl"} {
+		if !reflect.DeepEqual(settings["json"], settings[format]) {
+			t.Errorf("Loading the config in %v format --> Expected: %v, but got %v", format, settings["json"], settings[format])
+		}
+	}
+
+	if _, ok := settings["toml"]["server1"].(map[string]interface{})["max_conn"].(float64); !ok {
+		t.Errorf("Type of the numbers --> Expected: %T, but got %T", float64(0), settings["toml"]["server1"].(map[string]interface{})["max_conn"])
+	}
+}
@@ -0,0 +1,162 @@
+package config
+
+// Imports needed list
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MARK: Variables
+
+// sensitiveKeyRegexp - the names of the keys that their values are always redacted
+var sensitiveKeyRegexp = regexp.MustCompile(`(?i)(password|passwd|secret|token|api_?key|private_?key|credential)`)
+
+// MARK: Introspection
+
+// ModuleInfo - the effective config of the module and the source of its keys
+type ModuleInfo struct {
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type"`
+	Initialized bool                   `json:"initialized"`
+	Error       string                 `json:"error,omitempty"`
+	Settings    map[string]interface{} `json:"settings,omitempty"`
+	Sources     map[string]KeySource   `json:"sources,omitempty"`
+	EnvVars     []EnvOverride          `json:"env_vars,omitempty"`
+}
+
+// Introspection - the state of the config manager, the secrets of the settings are redacted
+type Introspection struct {
+	Name               string                `json:"name"`
+	Mode               string                `json:"mode"`
+	InitializedModules []string              `json:"initialized_modules"`
+	FailedModules      []string              `json:"failed_modules"`
+	Modules            map[string]ModuleInfo `json:"modules"`
+}
+
+// MARK: Private Functions
+
+// isSensitiveKey - check whether the last segment of the key path looks like a password, secret or token
+func isSensitiveKey(key string) bool {
+	parts := strings.Split(key, ".")
+	return sensitiveKeyRegexp.MatchString(parts[len(parts)-1])
+}
+
+// MARK: Private Methods
+
+// redactValue - redact the value of the key path if it is sensitive or is resolved from a secret
+func (w *ViperWrapper) redactValue(key string, value interface{}) interface{} {
+	if value == nil || value == "" {
+		return value
+	}
+	if isSensitiveKey(key) {
+		return RedactedValue
+	}
+
+	// the secret may be nested, so the maps are redacted key by key and the lists item by item
+	if v, ok := value.(map[string]interface{}); ok {
+		result := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			result[k] = w.redactValue(key+"."+k, item)
+		}
+		return result
+	}
+	if v, ok := value.([]interface{}); ok {
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = w.redactValue(key+"."+strconv.Itoa(i), item)
+		}
+		return result
+	}
+
+	if w.IsSecretKey(key) {
+		return RedactedValue
+	}
+	return RedactSecretValue(value)
+}
+
+// MARK: Public Methods
+
+// RedactedSettings - returns all settings of the module, the sensitive keys and the secrets are redacted
+func (w *ViperWrapper) RedactedSettings() map[string]interface{} {
+	settings := w.settings()
+
+	result := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		result[k] = w.redactValue(k, v)
+	}
+	return result
+}
+
+// Sources - returns the source of all leaf keys, the keys of a module without layers come from its config file or the map
+func (w *ViperWrapper) Sources() map[string]KeySource {
+	result := w.GetKeySources()
+
+	source := KeySource{}
+	if w.ConfigResourcePlace == "remote" {
+		source.Layer = "remote"
+	} else if w.ConfigData != nil {
+		source.Layer = MemoryLayer
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.Instance == nil {
+		return result
+	}
+	source.File = w.Instance.ConfigFileUsed()
+	for _, key := range w.Instance.AllKeys() {
+		if _, ok := result[key]; !ok {
+			result[key] = source
+		}
+	}
+	return result
+}
+
+// Introspect - returns the mode, the status of the modules and their effective redacted config with the source of the keys
+func (p *manager) Introspect() Introspection {
+	result := Introspection{
+		Name:               p.GetName(),
+		Mode:               p.GetOperationType(),
+		InitializedModules: p.GetAllInitializedModuleList(),
+		FailedModules:      []string{},
+		Modules:            make(map[string]ModuleInfo),
+	}
+	if result.InitializedModules == nil {
+		result.InitializedModules = []string{}
+	}
+	sort.Strings(result.InitializedModules)
+
+	p.statusLock.RLock()
+	for name, status := range p.modulesStatus {
+		info := ModuleInfo{Name: name, Type: "local", Initialized: status}
+		if err, ok := p.modulesError[name]; ok && err != nil {
+			info.Error = RedactSecrets(err.Error())
+		}
+		if !status {
+			result.FailedModules = append(result.FailedModules, name)
+		}
+		result.Modules[name] = info
+	}
+	p.statusLock.RUnlock()
+	sort.Strings(result.FailedModules)
+
+	for name, w := range p.modules {
+		info := result.Modules[name]
+		info.Name = name
+		info.Type = "local"
+		if w.ConfigResourcePlace == "remote" {
+			info.Type = "remote"
+		}
+		if info.Initialized {
+			info.Settings = w.RedactedSettings()
+			info.Sources = w.Sources()
+			info.EnvVars = w.EnvOverrides()
+		}
+		result.Modules[name] = info
+	}
+
+	return result
+}
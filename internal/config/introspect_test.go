@@ -0,0 +1,81 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestManagerIntrospect(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ZHYCAN_TEST_INTROSPECT_PASS", "introspect-pass")
+	content := `{"server1": {"host": "127.0.0.1", "password": "plain-pass", "dsn": "user:${env:ZHYCAN_TEST_INTROSPECT_PASS}@host", "auth": {"api_key": "k"}}, "connections": ["server1"]}`
+	_ = os.WriteFile(filepath.Join(dir, "db.json"), []byte(content), 0o644)
+
+	w := &ViperWrapper{ConfigPath: []string{dir}, ConfigName: "db"}
+	err := w.Load()
+	if err != nil {
+		t.Errorf("Loading the wrapper --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	p := &manager{
+		modules:       map[string]*ViperWrapper{"db": w},
+		modulesStatus: map[string]bool{"db": true, "cache": false},
+		configMode:    "test",
+	}
+	p.setModuleError("cache", errors.New("not found"))
+
+	result := p.Introspect()
+	if !reflect.DeepEqual([]string{"db"}, result.InitializedModules) || !reflect.DeepEqual([]string{"cache"}, result.FailedModules) {
+		t.Errorf("Status of the modules --> Expected: %v and %v, but got %v and %v", []string{"db"}, []string{"cache"}, result.InitializedModules, result.FailedModules)
+	}
+	if result.Modules["cache"].Error != "not found" {
+		t.Errorf("Error of the failed module --> Expected: %v, but got %v", "not found", result.Modules["cache"].Error)
+	}
+
+	expected := map[string]interface{}{
+		"server1": map[string]interface{}{
+			"host":     "127.0.0.1",
+			"password": RedactedValue,
+			"dsn":      RedactedValue,
+			"auth":     map[string]interface{}{"api_key": RedactedValue},
+		},
+		"connections": []interface{}{"server1"},
+	}
+	if !reflect.DeepEqual(expected, result.Modules["db"].Settings) {
+		t.Errorf("Redacted settings --> Expected: %v, but got %v", expected, result.Modules["db"].Settings)
+	}
+
+	source := result.Modules["db"].Sources["server1.host"]
+	if source.File != filepath.Join(dir, "db.json") {
+		t.Errorf("Source of the key --> Expected: %v, but got %v", filepath.Join(dir, "db.json"), source.File)
+	}
+}
+
+func TestRedactedSettingsInLists(t *testing.T) {
+	w := &ViperWrapper{ConfigName: "http", ConfigData: map[string]interface{}{
+		"servers": []interface{}{
+			map[string]interface{}{"name": "s1", "password": "hunter2", "config": map[string]interface{}{"api_token": "t1"}},
+			map[string]interface{}{"name": "s2", "tokens": []interface{}{"a", "b"}},
+		},
+		"hosts": []interface{}{"h1", "h2"},
+	}}
+	err := w.Load()
+	if err != nil {
+		t.Fatalf("Loading the wrapper --> Expected: %v, but got %v", nil, err)
+	}
+
+	expected := map[string]interface{}{
+		"servers": []interface{}{
+			map[string]interface{}{"name": "s1", "password": RedactedValue, "config": map[string]interface{}{"api_token": RedactedValue}},
+			map[string]interface{}{"name": "s2", "tokens": RedactedValue},
+		},
+		"hosts": []interface{}{"h1", "h2"},
+	}
+	if result := w.RedactedSettings(); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Redacted settings --> Expected: %v, but got %v", expected, result)
+	}
+}
@@ -5,27 +5,47 @@ import (
 	"fmt"
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
+	"io/fs"
 	"log"
 	"os"
+	"strings"
 	"sync"
-	"time"
 )
 
 // Mark: manager
 
 // Manager object
 type manager struct {
+	base          *viper.Viper
 	modules       map[string]*ViperWrapper
 	modulesStatus map[string]bool
+	modulesError  map[string]error
 
-	configBasePath string
-	configMode     string
+	configBasePath  string
+	configMode      string
+	configEnvPrefix string
+	configFS        fs.FS
+	configModules   map[string]map[string]interface{}
 
 	configRemoteAddress  string
 	configRemoteInfra    string
 	configRemoteDuration int64
+	remoteModules        []string
+	isLoaderRunning      bool
 
-	quitCh chan bool
+	statusLock sync.RWMutex
+	quitCh     chan bool
+}
+
+// Options - the params of a config manager that is created by New.
+// If `Modules` is set, the base config and the modules are built from the maps;
+// if `FS` is set (e.g. an embed.FS), the `configs` directory of the base path is read from it.
+type Options struct {
+	BasePath  string
+	Mode      string
+	EnvPrefix string
+	FS        fs.FS
+	Modules   map[string]map[string]interface{}
 }
 
 // MARK: Module variables
@@ -37,69 +57,93 @@ func init() {
 	log.Println("Initializing Config Provider ...")
 }
 
+// baseModule - the structure of every item of the `modules` in the base config
+type baseModule struct {
+	Name string `json:"name" validate:"required"`
+	Type string `json:"type" validate:"oneof=local remote"`
+}
+
 // MARK: Private Methods
 
+// newManager - create a manager that reads the base config by the viper instance
+func newManager(base *viper.Viper) *manager {
+	return &manager{
+		base:          base,
+		modules:       make(map[string]*ViperWrapper),
+		modulesStatus: make(map[string]bool),
+		modulesError:  make(map[string]error),
+		quitCh:        make(chan bool, 1),
+	}
+}
+
 // constructor - Constructor -> It initializes the config configuration params
-func constructor(configBasePath string, configInitialMode string, configEnvPrefix string) error {
+func (p *manager) constructor(configBasePath string, configInitialMode string, configEnvPrefix string) error {
 	log.Println("Config Manager Initializer ...")
 
-	providerInstance.configMode = configInitialMode
-	providerInstance.configBasePath = configBasePath
+	base := p.baseConfig()
+	p.configMode = configInitialMode
+	p.configBasePath = configBasePath
+	p.configEnvPrefix = configEnvPrefix
+
+	// every key of the base config can be overridden by the prefixed env, e.g. `ZHYCAN_CONFIG_MUST_WATCHED`;
+	// without the prefix just the explicit keys below are read, so the unrelated variables never leak into the config
+	base.SetEnvPrefix(configEnvPrefix)
+	base.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	if configEnvPrefix != "" {
+		base.AutomaticEnv()
+	}
 
-	viper.SetEnvPrefix(configEnvPrefix)
-	err := viper.BindEnv("mode")
+	err := base.BindEnv("mode")
 	if err != nil {
 		return err
 	}
 
-	err = viper.BindEnv("name")
+	err = base.BindEnv("name")
 	if err != nil {
 		return err
 	}
 
-	err = viper.BindEnv("config_remote_addr")
+	err = base.BindEnv("config_remote_addr")
 	if err != nil {
 		return err
 	}
 
-	err = viper.BindEnv("config_remote_infra")
+	err = base.BindEnv("config_remote_infra")
 	if err != nil {
 		return err
 	}
 
-	err = viper.BindEnv("config_remote_duration")
+	err = base.BindEnv("config_remote_duration")
 	if err != nil {
 		return err
 	}
 
-	mode := viper.Get("mode")
+	mode := base.Get("mode")
 	if mode != nil {
-		providerInstance.configMode = mode.(string)
+		p.configMode = mode.(string)
 	}
 
-	viper.AddConfigPath(fmt.Sprintf("%s/configs/%s/", providerInstance.configBasePath, providerInstance.configMode))
-	viper.SetConfigName("base")
-	err = viper.ReadInConfig()
+	err = p.readBaseConfig(base)
 	if err != nil {
 		return err
 	}
 
 	// Load all modules
-	configRemoteAddr := viper.GetString("config_remote_addr")
-	configRemoteInfra := viper.GetString("config_remote_infra")
-	configRemoteDuration := viper.GetInt64("config_remote_duration")
+	configRemoteAddr := base.GetString("config_remote_addr")
+	configRemoteInfra := base.GetString("config_remote_infra")
+	configRemoteDuration := base.GetInt64("config_remote_duration")
 
-	providerInstance.configRemoteInfra = configRemoteInfra
-	providerInstance.configRemoteAddress = configRemoteAddr
-	providerInstance.configRemoteDuration = configRemoteDuration
+	p.configRemoteInfra = configRemoteInfra
+	p.configRemoteAddress = configRemoteAddr
+	p.configRemoteDuration = configRemoteDuration
 
-	providerInstance.loadModules()
+	p.loadModules()
 
-	log.Printf("Read Base `%s` Configs", viper.GetString("name"))
-	mustWatched := viper.GetBool("config_must_watched")
-	if mustWatched {
-		viper.WatchConfig()
-		viper.OnConfigChange(func(in fsnotify.Event) {
+	log.Printf("Read Base `%s` Configs", base.GetString("name"))
+	mustWatched := base.GetBool("config_must_watched")
+	if mustWatched && p.configFS == nil && p.configModules == nil {
+		base.WatchConfig()
+		base.OnConfigChange(func(in fsnotify.Event) {
 			log.Println("Configs Changed: ", in.Name)
 		})
 	}
@@ -109,40 +153,119 @@ func constructor(configBasePath string, configInitialMode string, configEnvPrefi
 // loadModules - Loads All Modules That is configured in "init" config file
 func (p *manager) loadModules() {
 	log.Println("Load All Modules Config ...")
-	modules := viper.Get("modules")
+	var modules []baseModule
+	err := decodeValue("base", "modules", normalizeValue(p.baseConfig().Get("modules")), &modules)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
 
-	for _, item2 := range modules.([]interface{}) {
-		item := item2.(map[string]interface{})
-		name := item["name"].(string)
+	for _, item := range modules {
+		name := item.Name
+		resourcePlace := item.Type
+
+		if resourcePlace == "remote" {
+			w := &ViperWrapper{
+				Instance:            viper.New(),
+				ConfigName:          name,
+				ConfigEnvPrefix:     p.configEnvPrefix,
+				ConfigResourcePlace: resourcePlace,
+				AutomaticEnv:        p.configEnvPrefix != "",
+			}
+			w.Instance.SetConfigType("json")
+
+			p.modules[name] = w
+			p.setModuleStatus(name, false)
+			p.remoteModules = append(p.remoteModules, name)
+			continue
+		}
 
 		w := &ViperWrapper{
 			ConfigPath:          []string{fmt.Sprintf("%s/configs/%s/", p.configBasePath, p.configMode)},
-			ConfigName:          item["name"].(string),
-			ConfigResourcePlace: item["type"].(string),
+			ConfigLayers:        p.configLayers(),
+			ConfigName:          name,
+			ConfigEnvPrefix:     p.configEnvPrefix,
+			ConfigResourcePlace: resourcePlace,
+			ConfigFS:            p.configFS,
+			AutomaticEnv:        p.configEnvPrefix != "",
+		}
+
+		if p.configModules != nil {
+			data, ok := p.configModules[name]
+			if !ok {
+				p.setModuleStatus(name, false)
+				p.setModuleError(name, NewCategoryNotExistErr(name, fmt.Errorf("not found in the in-memory modules")))
+				continue
+			}
+			if data == nil {
+				data = make(map[string]interface{})
+			}
+			w.ConfigPath = nil
+			w.ConfigLayers = nil
+			w.ConfigData = data
 		}
 
 		err := w.Load()
 		if err == nil {
 			p.modules[name] = w
-			p.modulesStatus[name] = true
+			p.setModuleStatus(name, true)
 		} else {
-			p.modulesStatus[name] = false
+			p.setModuleStatus(name, false)
+			p.setModuleError(name, err)
 		}
 	}
-	//else if src == "remote" {
-	//	// Start a goroutine
-	//	for _, item := range modules {
-	//		w := &ViperWrapper{
-	//			ConfigName: item,
-	//		}
-	//
-	//		p.modules[item] = w
-	//		p.modulesStatus[item] = false
-	//	}
-	//
-	//	// start remote loader as go routines
-	//	go p.remoteConfigLoader()
-	//}
+
+	// start remote loader as go routines
+	if len(p.remoteModules) > 0 {
+		p.isLoaderRunning = true
+		go p.remoteConfigLoader()
+	}
+}
+
+// configLayers - returns the layers that every local module is merged from
+func (p *manager) configLayers() []ConfigLayer {
+	return defaultConfigLayers(p.configBasePath, p.configMode)
+}
+
+// defaultConfigLayers - returns the layers of the module files in the base path:
+// `configs/<name>`, then `configs/<mode>/<name>` and at last the git-ignored `configs/local/<name>`
+func defaultConfigLayers(configBasePath string, configMode string) []ConfigLayer {
+	return []ConfigLayer{
+		{Name: SharedLayer, Path: fmt.Sprintf("%s/configs/", configBasePath)},
+		{Name: ModeLayer, Path: fmt.Sprintf("%s/configs/%s/", configBasePath, configMode)},
+		{Name: LocalLayer, Path: fmt.Sprintf("%s/configs/local/", configBasePath)},
+	}
+}
+
+// baseConfig - returns the viper instance of the base config, the default manager uses the global viper,
+// also before it is created
+func (p *manager) baseConfig() *viper.Viper {
+	if p == nil || p.base == nil {
+		return viper.GetViper()
+	}
+	return p.base
+}
+
+// setModuleStatus - set the initialization status of the module
+func (p *manager) setModuleStatus(name string, status bool) {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+
+	p.modulesStatus[name] = status
+	if status {
+		delete(p.modulesError, name)
+	}
+}
+
+// setModuleError - keep the last error of loading the module
+func (p *manager) setModuleError(name string, err error) {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+
+	if p.modulesError == nil {
+		p.modulesError = make(map[string]error)
+	}
+	p.modulesError[name] = err
 }
 
 // MARK: Public Methods
@@ -153,23 +276,28 @@ func CreateManager(configBasePath string, configInitialMode string, configEnvPre
 	if providerInstance == nil {
 		var err error
 		once.Do(func() {
-			providerInstance = &manager{
-				modules:       make(map[string]*ViperWrapper),
-				modulesStatus: make(map[string]bool),
-				quitCh:        make(chan bool),
-			}
-
-			for item := range providerInstance.modulesStatus {
-				providerInstance.modulesStatus[item] = false
-			}
-
-			err = constructor(configBasePath, configInitialMode, configEnvPrefix)
+			providerInstance = newManager(viper.GetViper())
+			err = providerInstance.constructor(configBasePath, configInitialMode, configEnvPrefix)
 		})
 		return err
 	}
 	return nil
 }
 
+// New - create an independent manager with its own base config and modules, the default manager is not changed.
+// It is used by the tests and the embedded apps that need their own config tree.
+func New(opts Options) (*manager, error) {
+	p := newManager(viper.New())
+	p.configFS = opts.FS
+	p.configModules = opts.Modules
+	err := p.constructor(opts.BasePath, opts.Mode, opts.EnvPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetManager - returns the default manager that is created by CreateManager
 func GetManager() *manager {
 	return providerInstance
 }
@@ -185,7 +313,7 @@ func (p *manager) GetConfigWrapper(category string) (*ViperWrapper, error) {
 
 // GetName - returns service instance name based on config
 func (p *manager) GetName() string {
-	return viper.GetString("name")
+	return p.baseConfig().GetString("name")
 }
 
 // GetOperationType - returns operation type which could be `dev`, `prod`
@@ -212,6 +340,15 @@ func (p *manager) Get(category string, name string) (interface{}, error) {
 	return nil, NewCategoryNotExistErr(name, nil)
 }
 
+// Unmarshal - decode the value of the key in specific category into the out object
+func (p *manager) Unmarshal(category string, name string, out interface{}) error {
+	if val, ok := p.modules[category]; ok {
+		return val.Unmarshal(name, out)
+	}
+
+	return NewCategoryNotExistErr(category, nil)
+}
+
 // Set - set value in category by specified key.
 func (p *manager) Set(category string, name string, value interface{}) error {
 	if val, ok := p.modules[category]; ok {
@@ -221,15 +358,88 @@ func (p *manager) Set(category string, name string, value interface{}) error {
 	return NewCategoryNotExistErr(category, nil)
 }
 
-// StopLoader - stop remote loader
+// Update - apply all changes of the function to the category at once, see ViperWrapper.Update
+func (p *manager) Update(category string, fn func(tx *Tx) error) error {
+	if val, ok := p.modules[category]; ok {
+		return val.Update(fn)
+	}
+
+	return NewCategoryNotExistErr(category, nil)
+}
+
+// Rollback - restore the config file of the category to the snapshot version
+func (p *manager) Rollback(category string, version int) error {
+	if val, ok := p.modules[category]; ok {
+		return val.Rollback(version)
+	}
+
+	return NewCategoryNotExistErr(category, nil)
+}
+
+// Snapshots - returns the saved versions of the config file of the category
+func (p *manager) Snapshots(category string) ([]Snapshot, error) {
+	if val, ok := p.modules[category]; ok {
+		return val.Snapshots()
+	}
+
+	return nil, NewCategoryNotExistErr(category, nil)
+}
+
+// GetKeySource - returns the layer and the file that the value of the key in the category came from
+func (p *manager) GetKeySource(category string, name string) (KeySource, error) {
+	if val, ok := p.modules[category]; ok {
+		result, exist := val.GetKeySource(name)
+		if exist {
+			return result, nil
+		}
+
+		return KeySource{}, NewKeyNotExistErr(name, category, nil)
+	}
+
+	return KeySource{}, NewCategoryNotExistErr(category, nil)
+}
+
+// GetEnvOverrides - returns the env variables that are applied on the category, the sensitive values are redacted
+func (p *manager) GetEnvOverrides(category string) ([]EnvOverride, error) {
+	if val, ok := p.modules[category]; ok {
+		return val.EnvOverrides(), nil
+	}
+
+	return nil, NewCategoryNotExistErr(category, nil)
+}
+
+// GetKeySources - returns the layer and the file of all keys in the category
+func (p *manager) GetKeySources(category string) (map[string]KeySource, error) {
+	if val, ok := p.modules[category]; ok {
+		return val.GetKeySources(), nil
+	}
+
+	return nil, NewCategoryNotExistErr(category, nil)
+}
+
+// Subscribe - call the listener with the changes of the key path in the category whenever they change
+func (p *manager) Subscribe(category string, keyPath string, listener ChangeListener) error {
+	if val, ok := p.modules[category]; ok {
+		val.Subscribe(keyPath, listener)
+		return nil
+	}
+
+	return NewCategoryNotExistErr(category, nil)
+}
+
+// StopLoader - stop remote loader, it does not wait for the fetch that is running, the loader stops after it
 func (p *manager) StopLoader() {
-	//if p.ConfigSrc == "remote" {
-	//	p.quitCh <- true
-	//}
+	if p.isLoaderRunning {
+		p.quitCh <- true
+		p.isLoaderRunning = false
+	}
 }
 
 // IsInitialized - iterate over all config wrappers and see all initialised correctly
 func (p *manager) IsInitialized() bool {
+	p.statusLock.RLock()
+	defer p.statusLock.RUnlock()
+
 	flag := true
 	for _, value := range p.modulesStatus {
 		if value == false {
@@ -242,6 +452,9 @@ func (p *manager) IsInitialized() bool {
 
 // GetAllInitializedModuleList - get list of names that initialized truly
 func (p *manager) GetAllInitializedModuleList() []string {
+	p.statusLock.RLock()
+	defer p.statusLock.RUnlock()
+
 	var result []string
 	for key, val := range p.modulesStatus {
 		if val {
@@ -252,76 +465,24 @@ func (p *manager) GetAllInitializedModuleList() []string {
 	return result
 }
 
-// remoteConfigLoader - get configs from remote
-func (p *manager) remoteConfigLoader() {
-	for {
-		select {
-		case <-p.quitCh:
-			return
-		default:
-			for key := range p.modulesStatus {
-				data, err := p.remoteConfigLoad(key)
-				if err == nil {
-					err = p.modules[key].LoadFromRemote(data)
-					if err == nil {
-						p.modulesStatus[key] = true
-					} else {
-						log.Println(err.Error())
-					}
-				} else {
-					log.Println(err.Error())
-				}
-			}
-		}
-
-		time.Sleep(time.Duration(p.configRemoteDuration) * time.Second)
-	}
-}
-
-// remoteConfigLoad
-func (p *manager) remoteConfigLoad(key string) ([]byte, error) {
-	//if p.ConfigRemoteAddress != "" {
-	//	if p.ConfigRemoteInfra == "grpc" {
-	//		conn, err := grpc.Dial(p.ConfigRemoteAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	//		if err != nil {
-	//			return nil, protoerror.GrpcDialError{Addr: p.ConfigRemoteAddress, Err: err}
-	//		}
-	//		defer conn.Close()
-	//
-	//		localContext, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	//		defer cancel()
-	//
-	//		c := api.NewHelpClient(conn)
-	//		response, err := c.GetServiceConfig(localContext, &api.ServiceConfigRequest{
-	//			Section:       key,
-	//			Hostname:      p.GetHostName(),
-	//			ConstructorId: api.ConstructorId_V1901,
-	//			ServiceName:   p.GetName(),
-	//			MsgId:         timestamppb.Now()})
-	//		if err != nil {
-	//			return nil, RemoteResponseError{Err: err}
-	//		}
-	//
-	//		return []byte(response.Data), nil
-	//	}
-	//}
-	return nil, NewRemoteLoadErr(key, nil)
-}
-
 // ManualLoadConfig - load manual config from the path and add to the current dict
 func (p *manager) ManualLoadConfig(configBasePath string, configName string) error {
 	w := &ViperWrapper{
 		ConfigPath:          []string{configBasePath},
 		ConfigName:          configName,
+		ConfigEnvPrefix:     p.configEnvPrefix,
 		ConfigResourcePlace: "",
+		ConfigFS:            p.configFS,
+		AutomaticEnv:        p.configEnvPrefix != "",
 	}
 
 	err := w.Load()
 	if err == nil {
 		p.modules[configName] = w
-		p.modulesStatus[configName] = true
+		p.setModuleStatus(configName, true)
 	} else {
-		p.modulesStatus[configName] = false
+		p.setModuleStatus(configName, false)
+		p.setModuleError(configName, err)
 		return err
 	}
 	return nil
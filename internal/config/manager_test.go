@@ -1,6 +1,16 @@
 package config
 
 import (
+	"context"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -106,6 +116,179 @@ func TestManager_ManualLoadConfig(t *testing.T) {
 	}
 }
 
+func TestManager_RemoteConfigLoadHttp(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != RemoteConfigHttpPath+"server" || r.URL.Query().Get("mode") != "test" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"addr": ":4000"}`))
+	}))
+	defer srv.Close()
+
+	m := createRemoteManager(srv.URL, RemoteInfraHttp)
+	m.remoteConfigLoadAll()
+
+	if !m.IsInitialized() {
+		t.Errorf("Remote module must be initialized --> Expected: %v, but got %v", true, m.IsInitialized())
+		return
+	}
+
+	expectedValue := ":4000"
+	val, err := m.Get("server", "addr")
+	if err != nil || !reflect.DeepEqual(expectedValue, val) {
+		t.Errorf("Get remote value for key `addr` --> Expected: %v, but got %v (%v)", expectedValue, val, err)
+	}
+}
+
+func TestManager_RemoteConfigLoadGrpc(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Errorf("Cannot listen on local address --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	s := grpc.NewServer()
+	RegisterRemoteConfigServer(s, func(ctx context.Context, req *structpb.Struct) (*wrapperspb.BytesValue, error) {
+		section := req.GetFields()["section"].GetStringValue()
+		return wrapperspb.Bytes([]byte(`{"section": "` + section + `"}`)), nil
+	})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	m := createRemoteManager(lis.Addr().String(), RemoteInfraGrpc)
+	m.remoteConfigLoadAll()
+
+	expectedValue := "server"
+	val, err := m.Get("server", "section")
+	if err != nil || !reflect.DeepEqual(expectedValue, val) {
+		t.Errorf("Get remote value for key `section` --> Expected: %v, but got %v (%v)", expectedValue, val, err)
+	}
+}
+
+func createConfigTree(t *testing.T, name string, host string) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"configs/test/base.json": `{"name": "` + name + `", "modules": [{"name": "db", "type": "local"}]}`,
+		"configs/test/db.json":   `{"server1": {"host": "` + host + `"}}`,
+	}
+	for file, content := range files {
+		path := filepath.Join(root, file)
+		_ = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		_ = os.WriteFile(path, []byte(content), 0o644)
+	}
+	return root
+}
+
+func TestNewManager(t *testing.T) {
+	first, err := New(Options{BasePath: createConfigTree(t, "first", "10.0.0.1"), Mode: "test", EnvPrefix: "ZHYCAN_FIRST"})
+	if err != nil {
+		t.Errorf("Creating the first manager --> Expected: %v, but got %v", nil, err)
+		return
+	}
+	second, err := New(Options{BasePath: createConfigTree(t, "second", "10.0.0.2"), Mode: "test", EnvPrefix: "ZHYCAN_SECOND"})
+	if err != nil {
+		t.Errorf("Creating the second manager --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	if first.GetName() != "first" || second.GetName() != "second" {
+		t.Errorf("Names of the managers --> Expected: %v and %v, but got %v and %v", "first", "second", first.GetName(), second.GetName())
+	}
+
+	for _, item := range []struct {
+		source   Provider
+		expected string
+	}{{first, "10.0.0.1"}, {second, "10.0.0.2"}} {
+		host, err := DecodeFrom[string](item.source, "db", "server1.host")
+		if err != nil || host != item.expected {
+			t.Errorf("Value of the key in its own tree --> Expected: %v, but got %v (%v)", item.expected, host, err)
+		}
+	}
+
+	if viper.GetString("name") == "first" || viper.GetString("name") == "second" {
+		t.Errorf("The global viper --> Expected to be untouched, but got name: %v", viper.GetString("name"))
+	}
+}
+
+func TestManager_NilGetName(t *testing.T) {
+	viper.Set("name", "nil-manager")
+	defer viper.Set("name", nil)
+
+	var m *manager
+	if name := m.GetName(); name != "nil-manager" {
+		t.Errorf("Name of the nil manager --> Expected: %v, but got %v", "nil-manager", name)
+	}
+}
+
+func TestManager_StopLoader(t *testing.T) {
+	m := createRemoteManager("127.0.0.1:1", RemoteInfraHttp)
+	m.configRemoteDuration = 1
+	m.isLoaderRunning = true
+	go m.remoteConfigLoader()
+
+	done := make(chan bool)
+	go func() {
+		m.StopLoader()
+		done <- true
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Stopping the remote loader --> Expected to be stopped, but it is still running")
+	}
+}
+
+func TestManager_StopLoaderDuringFetch(t *testing.T) {
+	m := createRemoteManager("127.0.0.1:1", RemoteInfraHttp)
+	m.isLoaderRunning = true
+
+	// the loader is busy, so nothing is receiving from the channel
+	done := make(chan bool)
+	go func() {
+		m.StopLoader()
+		done <- true
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Stopping the busy remote loader --> Expected to return, but it is blocked")
+	}
+
+	stopped := make(chan bool)
+	go func() {
+		m.remoteConfigLoader()
+		stopped <- true
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Remote loader after the fetch --> Expected to be stopped, but it is still running")
+	}
+}
+
+func createRemoteManager(addr string, infra string) *manager {
+	w := &ViperWrapper{
+		Instance:            viper.New(),
+		ConfigName:          "server",
+		ConfigResourcePlace: "remote",
+	}
+	w.Instance.SetConfigType("json")
+
+	return &manager{
+		modules:             map[string]*ViperWrapper{"server": w},
+		modulesStatus:       map[string]bool{"server": false},
+		configMode:          "test",
+		configRemoteAddress: addr,
+		configRemoteInfra:   infra,
+		remoteModules:       []string{"server"},
+		quitCh:              make(chan bool, 1),
+	}
+}
+
 func createManager() error {
 	path := "../.."
 	initialMode := "test"
@@ -0,0 +1,207 @@
+package config
+
+// Imports needed list
+import (
+	"bytes"
+	"fmt"
+	"github.com/spf13/viper"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MARK: Private Functions
+
+// fsPath - convert the directory to the form of the fs.FS paths, e.g. `./configs/test/` to `configs/test`
+func fsPath(dir string) string {
+	p := path.Clean("/" + filepath.ToSlash(dir))
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+// findFSConfigFile - returns the config file with the name and one of the supported extensions inside the directory of the file system
+func findFSConfigFile(fsys fs.FS, dir string, name string) string {
+	for _, ext := range viper.SupportedExts {
+		file := path.Join(fsPath(dir), name+"."+ext)
+		if info, err := fs.Stat(fsys, file); err == nil && !info.IsDir() {
+			return file
+		}
+	}
+	return ""
+}
+
+// readFSConfig - read the config file of the file system into the viper instance, the format comes from its extension
+func readFSConfig(fsys fs.FS, file string, instance *viper.Viper) error {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return err
+	}
+
+	instance.SetConfigType(strings.TrimPrefix(path.Ext(file), "."))
+	return instance.ReadConfig(bytes.NewReader(data))
+}
+
+// MARK: Private Methods
+
+// isVirtual - check whether the module is built from a map or read from a file system other than the os,
+// these modules are neither watched nor written back to a file
+func (w *ViperWrapper) isVirtual() bool {
+	return w.ConfigData != nil || w.ConfigFS != nil
+}
+
+// readFile - read the config file from the `ConfigFS` if it is set, otherwise from the os
+func (w *ViperWrapper) readFile(file string) (*viper.Viper, error) {
+	instance := viper.New()
+	if w.ConfigFS != nil {
+		err := readFSConfig(w.ConfigFS, file, instance)
+		if err != nil {
+			return nil, err
+		}
+		return instance, nil
+	}
+
+	instance.SetConfigFile(file)
+	err := instance.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// loadFSFile - read the module file from the first path of the `ConfigPath` that has it inside the `ConfigFS`
+func (w *ViperWrapper) loadFSFile() (*viper.Viper, error) {
+	file := ""
+	for _, dir := range w.ConfigPath {
+		file = findFSConfigFile(w.ConfigFS, dir, w.ConfigName)
+		if file != "" {
+			break
+		}
+	}
+	if file == "" {
+		return nil, NewCategoryNotExistErr(w.ConfigName, fmt.Errorf("not found in the file system: %v", w.ConfigPath))
+	}
+
+	fileInstance, err := w.readFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := viper.New()
+	instance.SetConfigFile(file)
+	err = instance.MergeConfigMap(normalizeFileSettings(file, fileInstance.AllSettings()))
+	if err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// loadMemory - build the instance from the `ConfigData`, the map is copied so the caller can not change it later
+func (w *ViperWrapper) loadMemory() (*viper.Viper, error) {
+	instance := viper.New()
+	err := instance.MergeConfigMap(normalizeSettings(w.ConfigData))
+	if err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// updateMemory - apply the changes of the transaction on the `ConfigData` and load it again, nothing is written to a file
+func (w *ViperWrapper) updateMemory(fn func(tx *Tx) error) error {
+	w.txLock.Lock()
+	defer w.txLock.Unlock()
+
+	w.lock.Lock()
+	tx := &Tx{category: w.ConfigName, settings: copySettings(w.rawSettings)}
+	w.lock.Unlock()
+
+	err := fn(tx)
+	if err != nil {
+		return err
+	}
+	if len(tx.operations) == 0 {
+		return nil
+	}
+
+	previousData := w.ConfigData
+	settings := normalizeSettings(previousData)
+	tx.apply(settings)
+
+	previous := w.settings()
+	w.ConfigData = settings
+	err = w.Load()
+	if err != nil {
+		w.ConfigData = previousData
+		_ = w.Load()
+		return NewUpdateErr(w.ConfigName, err)
+	}
+
+	w.notifyChange(diffSettings(w.ConfigName, previous, w.settings()))
+	return nil
+}
+
+// readBaseConfig - read the base config from the in-memory modules, the file system or the base path in order
+func (p *manager) readBaseConfig(base *viper.Viper) error {
+	if p.configModules != nil {
+		err := base.MergeConfigMap(p.memoryBaseSettings())
+		if err != nil {
+			return err
+		}
+		if mode := base.GetString("mode"); mode != "" {
+			p.configMode = mode
+		}
+		return nil
+	}
+
+	if p.configFS != nil {
+		dir := fmt.Sprintf("%s/configs/%s/", p.configBasePath, p.configMode)
+		file := findFSConfigFile(p.configFS, dir, "base")
+		if file == "" {
+			return NewCategoryNotExistErr("base", fmt.Errorf("not found in the file system: %s", fsPath(dir)))
+		}
+		return readFSConfig(p.configFS, file, base)
+	}
+
+	base.AddConfigPath(fmt.Sprintf("%s/configs/%s/", p.configBasePath, p.configMode))
+	base.SetConfigName("base")
+	return base.ReadInConfig()
+}
+
+// memoryBaseSettings - returns the `base` of the in-memory modules, if it has no `modules` list
+// all other modules are listed as local modules
+func (p *manager) memoryBaseSettings() map[string]interface{} {
+	settings := make(map[string]interface{})
+	if base, ok := p.configModules["base"]; ok && base != nil {
+		settings = normalizeSettings(base)
+	}
+
+	if _, ok := settings["modules"]; !ok {
+		var names []string
+		for name := range p.configModules {
+			if name != "base" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		modules := make([]interface{}, len(names))
+		for i, name := range names {
+			modules[i] = map[string]interface{}{"name": name, "type": "local"}
+		}
+		settings["modules"] = modules
+	}
+	return settings
+}
+
+// MARK: Public Functions
+
+// FromMap - create an independent manager that every module of it is built from the map, e.g. `"db": {"default": "sqlite"}`.
+// The `base` entry is the base config; if it does not list the `modules`, all other entries are loaded as local modules.
+// Nothing is read from or written to the files and the env variables are ignored, so it fits the unit tests.
+func FromMap(modules map[string]map[string]interface{}) (*manager, error) {
+	return New(Options{Mode: "test", Modules: modules})
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestFromMap(t *testing.T) {
+	p, err := FromMap(map[string]map[string]interface{}{
+		"base": {"name": "memory-app"},
+		"db": {
+			"default": "sqlite",
+			"sqlite":  map[string]interface{}{"db": "test.db", "max_open": 10},
+		},
+		"logger": {"type": "console"},
+	})
+	if err != nil {
+		t.Fatalf("Create manager from map --> Expected: %v, but got %v", nil, err)
+	}
+
+	if p.GetName() != "memory-app" || p.GetOperationType() != "test" {
+		t.Errorf("Name and mode --> Expected: %v, but got %v", "memory-app test", p.GetName()+" "+p.GetOperationType())
+	}
+	if !p.IsInitialized() {
+		t.Errorf("Initialization status --> Expected: %v, but got %v", true, false)
+	}
+
+	value, err := p.Get("db", "sqlite.max_open")
+	if err != nil || value != float64(10) {
+		t.Errorf("Get the value --> Expected: %v, but got %v (%v)", float64(10), value, err)
+	}
+
+	var changed []string
+	err = p.Subscribe("db", "sqlite", func(event ChangeEvent) {
+		changed = append(changed, event.Roots()...)
+	})
+	if err != nil {
+		t.Fatalf("Subscribe --> Expected: %v, but got %v", nil, err)
+	}
+
+	err = p.Set("db", "sqlite.db", "other.db")
+	if err != nil {
+		t.Fatalf("Set the value in memory --> Expected: %v, but got %v", nil, err)
+	}
+	value, _ = p.Get("db", "sqlite.db")
+	if value != "other.db" {
+		t.Errorf("Get the changed value --> Expected: %v, but got %v", "other.db", value)
+	}
+	if len(changed) != 1 || changed[0] != "sqlite" {
+		t.Errorf("Change notification --> Expected: %v, but got %v", []string{"sqlite"}, changed)
+	}
+
+	_, err = p.Snapshots("db")
+	if err == nil {
+		t.Errorf("Snapshots of the in-memory module --> Expected an error, but got %v", nil)
+	}
+}
+
+func TestFromMapMissingModule(t *testing.T) {
+	p, err := FromMap(map[string]map[string]interface{}{
+		"base": {
+			"modules": []interface{}{
+				map[string]interface{}{"name": "db", "type": "local"},
+				map[string]interface{}{"name": "cache", "type": "local"},
+			},
+		},
+		"db": {"default": "sqlite"},
+	})
+	if err != nil {
+		t.Fatalf("Create manager from map --> Expected: %v, but got %v", nil, err)
+	}
+
+	if p.IsInitialized() {
+		t.Errorf("Initialization status --> Expected: %v, but got %v", false, true)
+	}
+	info := p.Introspect()
+	if len(info.FailedModules) != 1 || info.FailedModules[0] != "cache" {
+		t.Errorf("Failed modules --> Expected: %v, but got %v", []string{"cache"}, info.FailedModules)
+	}
+	if source, _ := p.GetKeySources("db"); len(source) != 0 {
+		t.Errorf("Key sources of the in-memory module --> Expected: %v, but got %v", 0, len(source))
+	}
+	if info.Modules["db"].Sources["default"].Layer != MemoryLayer {
+		t.Errorf("Source layer --> Expected: %v, but got %v", MemoryLayer, info.Modules["db"].Sources["default"].Layer)
+	}
+}
+
+func TestNewWithFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"configs/test/base.json": {Data: []byte(`{"name": "fs-app", "modules": [{"name": "db", "type": "local"}]}`)},
+		"configs/db.json":        {Data: []byte(`{"default": "sqlite", "sqlite": {"db": "shared.db", "max_open": 5}}`)},
+		"configs/test/db.yaml":   {Data: []byte("sqlite:\n  db: test.db\n")},
+	}
+
+	p, err := New(Options{Mode: "test", FS: fsys})
+	if err != nil {
+		t.Fatalf("Create manager from file system --> Expected: %v, but got %v", nil, err)
+	}
+	if p.GetName() != "fs-app" || !p.IsInitialized() {
+		t.Errorf("Name and status --> Expected: %v, but got %v", "fs-app true", p.GetName())
+	}
+
+	value, _ := p.Get("db", "sqlite.db")
+	if value != "test.db" {
+		t.Errorf("Value of the mode layer --> Expected: %v, but got %v", "test.db", value)
+	}
+	value, _ = p.Get("db", "sqlite.max_open")
+	if value != float64(5) {
+		t.Errorf("Value of the shared layer --> Expected: %v, but got %v", float64(5), value)
+	}
+
+	source, _ := p.GetKeySource("db", "sqlite.db")
+	if source.Layer != ModeLayer || source.File != "configs/test/db.yaml" {
+		t.Errorf("Key source --> Expected: %v, but got %v", "mode configs/test/db.yaml", source)
+	}
+
+	err = p.Set("db", "sqlite.db", "other.db")
+	if err == nil {
+		t.Errorf("Set on the read-only file system --> Expected an error, but got %v", nil)
+	}
+
+	_, err = New(Options{Mode: "prod", FS: fsys})
+	if err == nil {
+		t.Errorf("Base config of a missing mode --> Expected an error, but got %v", nil)
+	}
+}
+
+func TestFromMapBoundEnv(t *testing.T) {
+	t.Setenv("NAME", "env-app")
+	t.Setenv("CONFIG_MUST_WATCHED", "true")
+
+	p, err := FromMap(map[string]map[string]interface{}{
+		"base": {"name": "memory-app"},
+	})
+	if err != nil {
+		t.Fatalf("Create manager from map --> Expected: %v, but got %v", nil, err)
+	}
+
+	// the explicit keys are bound without the prefix, the others are never read from the env
+	if p.GetName() != "env-app" {
+		t.Errorf("Name from the bound env --> Expected: %v, but got %v", "env-app", p.GetName())
+	}
+	if p.baseConfig().GetBool("config_must_watched") {
+		t.Errorf("Key from the unbound env --> Expected: %v, but got %v", false, true)
+	}
+}
@@ -0,0 +1,47 @@
+package config
+
+// MARK: Provider
+
+// Provider - the source of the configs that the subsystem managers read from.
+// The default manager and the managers that are created by New satisfy it.
+type Provider interface {
+	GetName() string
+	GetOperationType() string
+	GetHostName() string
+	Get(category string, name string) (interface{}, error)
+	Unmarshal(category string, name string, out interface{}) error
+	GetConfigWrapper(category string) (*ViperWrapper, error)
+	Subscribe(category string, keyPath string, listener ChangeListener) error
+	IsInitialized() bool
+	Introspect() Introspection
+}
+
+// MARK: Public Functions
+
+// DecodeFrom - decode the value of the key in the category of the provider into a new object of type T.
+// An empty key decodes the whole category.
+func DecodeFrom[T any](p Provider, category string, key string) (T, error) {
+	var result T
+	err := p.Unmarshal(category, key, &result)
+	return result, err
+}
+
+// DecodeOptionalFrom - the same as DecodeFrom, but the missing key is decoded from the `default` tags of T,
+// so the optional config blocks can be left out
+func DecodeOptionalFrom[T any](p Provider, category string, key string) (T, error) {
+	var result T
+	err := UnmarshalOptional(p, category, key, &result)
+	return result, err
+}
+
+// UnmarshalOptional - decode the value of the key into the out object, the missing key just applies the `default` tags
+func UnmarshalOptional(p Provider, category string, key string, out interface{}) error {
+	err := p.Unmarshal(category, key, out)
+	if IsKeyNotExist(err) {
+		return decodeValue(category, key, nil, out)
+	}
+	return err
+}
+
+// check that the manager satisfies the Provider
+var _ Provider = (*manager)(nil)
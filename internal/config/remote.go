@@ -0,0 +1,205 @@
+package config
+
+// Imports needed list
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MARK: Constants
+
+// Supported values of `config_remote_infra`
+const (
+	RemoteInfraGrpc = "grpc"
+	RemoteInfraHttp = "http"
+)
+
+const (
+	// RemoteConfigServiceName - full name of the gRPC service that serves the configs
+	RemoteConfigServiceName = "zhycan.config.ConfigService"
+	// RemoteConfigMethod - full method name that is invoked to fetch a module config
+	RemoteConfigMethod = "/" + RemoteConfigServiceName + "/GetConfig"
+	// RemoteConfigHttpPath - path prefix of the HTTP/JSON config endpoint
+	RemoteConfigHttpPath = "/configs/"
+
+	remoteRequestTimeout  = 2 * time.Second
+	defaultRemoteDuration = 300 * time.Second
+)
+
+// RemoteConfigHandler - the function that config servers implement to answer a config request.
+// The request holds `section`, `hostname`, `service_name` and `mode` string fields and
+// the response must carry the raw JSON content of the requested module.
+type RemoteConfigHandler func(ctx context.Context, req *structpb.Struct) (*wrapperspb.BytesValue, error)
+
+// MARK: Public Functions
+
+// RegisterRemoteConfigServer - register a config service on the given gRPC server,
+// so that other services can load their `remote` modules from it
+func RegisterRemoteConfigServer(s *grpc.Server, handler RemoteConfigHandler) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: RemoteConfigServiceName,
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "GetConfig",
+				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+					in := new(structpb.Struct)
+					if err := dec(in); err != nil {
+						return nil, err
+					}
+					if interceptor == nil {
+						return handler(ctx, in)
+					}
+					info := &grpc.UnaryServerInfo{
+						Server:     srv,
+						FullMethod: RemoteConfigMethod,
+					}
+					return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+						return handler(ctx, req.(*structpb.Struct))
+					})
+				},
+			},
+		},
+		Streams: []grpc.StreamDesc{},
+	}, struct{}{})
+}
+
+// MARK: Private Methods
+
+// remoteConfigLoadGrpc - fetch the module config from the gRPC config server
+func (p *manager) remoteConfigLoadGrpc(key string) ([]byte, error) {
+	conn, err := grpc.Dial(p.configRemoteAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, NewRemoteLoadErr(key, err)
+	}
+	defer conn.Close()
+
+	localContext, cancel := context.WithTimeout(context.Background(), remoteRequestTimeout)
+	defer cancel()
+
+	request, err := structpb.NewStruct(map[string]interface{}{
+		"section":      key,
+		"hostname":     p.GetHostName(),
+		"service_name": p.GetName(),
+		"mode":         p.configMode,
+	})
+	if err != nil {
+		return nil, NewRemoteLoadErr(key, err)
+	}
+
+	response := new(wrapperspb.BytesValue)
+	err = conn.Invoke(localContext, RemoteConfigMethod, request, response)
+	if err != nil {
+		return nil, NewRemoteResponseErr(err)
+	}
+
+	return response.GetValue(), nil
+}
+
+// remoteConfigLoadHttp - fetch the module config from the HTTP/JSON config server
+func (p *manager) remoteConfigLoadHttp(key string) ([]byte, error) {
+	addr := p.configRemoteAddress
+	if !strings.HasPrefix(addr, "http://") && !strings.HasPrefix(addr, "https://") {
+		addr = "http://" + addr
+	}
+
+	query := url.Values{}
+	query.Set("hostname", p.GetHostName())
+	query.Set("service_name", p.GetName())
+	query.Set("mode", p.configMode)
+
+	requestUrl := fmt.Sprintf("%s%s%s?%s", strings.TrimSuffix(addr, "/"), RemoteConfigHttpPath, url.PathEscape(key), query.Encode())
+
+	localContext, cancel := context.WithTimeout(context.Background(), remoteRequestTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(localContext, http.MethodGet, requestUrl, nil)
+	if err != nil {
+		return nil, NewRemoteLoadErr(key, err)
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, NewRemoteResponseErr(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, NewRemoteResponseErr(fmt.Errorf("unexpected status code: %d", response.StatusCode))
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, NewRemoteResponseErr(err)
+	}
+
+	return data, nil
+}
+
+// remoteConfigLoad - fetch the module config from the remote based on `config_remote_infra`
+func (p *manager) remoteConfigLoad(key string) ([]byte, error) {
+	if p.configRemoteAddress == "" {
+		return nil, NewRemoteLoadErr(key, errors.New("`config_remote_addr` is empty"))
+	}
+
+	switch strings.ToLower(p.configRemoteInfra) {
+	case RemoteInfraGrpc:
+		return p.remoteConfigLoadGrpc(key)
+	case RemoteInfraHttp:
+		return p.remoteConfigLoadHttp(key)
+	}
+
+	return nil, NewRemoteLoadErr(key, fmt.Errorf("not supported remote infra: %v", p.configRemoteInfra))
+}
+
+// remoteConfigLoadAll - load all remote modules once and update their status
+func (p *manager) remoteConfigLoadAll() {
+	for _, key := range p.remoteModules {
+		data, err := p.remoteConfigLoad(key)
+		if err == nil {
+			err = p.modules[key].LoadFromRemote(data)
+			if err == nil {
+				p.setModuleStatus(key, true)
+			} else {
+				p.setModuleError(key, err)
+				log.Println(err.Error())
+			}
+		} else {
+			p.setModuleError(key, err)
+			log.Println(err.Error())
+		}
+	}
+}
+
+// remoteConfigLoader - get configs from remote and refresh them every `config_remote_duration` seconds
+func (p *manager) remoteConfigLoader() {
+	duration := time.Duration(p.configRemoteDuration) * time.Second
+	if duration <= 0 {
+		duration = defaultRemoteDuration
+	}
+
+	ticker := time.NewTicker(duration)
+	defer ticker.Stop()
+
+	p.remoteConfigLoadAll()
+	for {
+		select {
+		case <-p.quitCh:
+			return
+		case <-ticker.C:
+			p.remoteConfigLoadAll()
+		}
+	}
+}
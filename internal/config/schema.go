@@ -0,0 +1,454 @@
+package config
+
+// Imports needed list
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MARK: Constants
+
+// SchemaDraft - the JSON Schema draft that the exported schemas declare
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// MARK: Schema
+
+// Schema - the subset of JSON Schema that is used to describe and validate the config modules
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Const                interface{}        `json:"const,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	If                   *Schema            `json:"if,omitempty"`
+	Then                 *Schema            `json:"then,omitempty"`
+}
+
+// ModuleValidation - the result of validating one module of `base.json` against its schema
+type ModuleValidation struct {
+	Name         string
+	Type         string
+	HasSchema    bool
+	Errors       []error
+	IsSkipped    bool
+	SkipReason   string
+	LoadingError error
+}
+
+// MARK: Private Functions
+
+// schemaTypes - returns the list of JSON types that the schema accepts
+func (s *Schema) schemaTypes() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	}
+	return nil
+}
+
+// jsonType - returns the JSON type name of the decoded config value
+func jsonType(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); f == float64(int64(f)) {
+			return "integer"
+		}
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return v.Kind().String()
+}
+
+// toFloat - convert the numeric value to float64
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// schemaValueEqual - compare the config value with the value of `enum` or `const`
+func schemaValueEqual(a interface{}, b interface{}) bool {
+	fa, okA := toFloat(a)
+	fb, okB := toFloat(b)
+	if okA && okB {
+		return fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// validate - validate the value and append the errors with their exact key path
+func (s *Schema) validate(category string, path string, value interface{}, errs *[]error) {
+	if s == nil {
+		return
+	}
+
+	if types := s.schemaTypes(); len(types) > 0 {
+		actual := jsonType(value)
+		matched := false
+		for _, t := range types {
+			if t == actual || (t == "number" && actual == "integer") {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			*errs = append(*errs, NewSchemaErr(category, path, fmt.Sprintf("expected %s, but got %s", strings.Join(types, " or "), actual)))
+			return
+		}
+	}
+
+	if s.Const != nil && !schemaValueEqual(value, s.Const) {
+		*errs = append(*errs, NewSchemaErr(category, path, fmt.Sprintf("must be %v, but got %v", s.Const, value)))
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, item := range s.Enum {
+			if schemaValueEqual(value, item) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*errs = append(*errs, NewSchemaErr(category, path, fmt.Sprintf("must be one of %v, but got %v", s.Enum, value)))
+		}
+	}
+
+	if number, ok := toFloat(value); ok {
+		if s.Minimum != nil && number < *s.Minimum {
+			*errs = append(*errs, NewSchemaErr(category, path, fmt.Sprintf("must be >= %v, but got %v", *s.Minimum, value)))
+		}
+		if s.Maximum != nil && number > *s.Maximum {
+			*errs = append(*errs, NewSchemaErr(category, path, fmt.Sprintf("must be <= %v, but got %v", *s.Maximum, value)))
+		}
+	}
+
+	if str, ok := value.(string); ok && s.MinLength != nil && len(str) < *s.MinLength {
+		*errs = append(*errs, NewSchemaErr(category, path, fmt.Sprintf("must have at least %d characters", *s.MinLength)))
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if s.MinItems != nil && v.Len() < *s.MinItems {
+			*errs = append(*errs, NewSchemaErr(category, path, fmt.Sprintf("must have at least %d items, but got %d", *s.MinItems, v.Len())))
+		}
+		if s.Items != nil {
+			for i := 0; i < v.Len(); i++ {
+				s.Items.validate(category, fmt.Sprintf("%s[%d]", path, i), v.Index(i).Interface(), errs)
+			}
+		}
+	case reflect.Map:
+		object := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			object[strings.ToLower(fmt.Sprintf("%v", iter.Key().Interface()))] = iter.Value().Interface()
+		}
+
+		for _, name := range s.Required {
+			if _, ok := object[strings.ToLower(name)]; !ok {
+				*errs = append(*errs, NewSchemaErr(category, joinKeyPath(path, name), "is required"))
+			}
+		}
+
+		// iterate in order to report the errors deterministically
+		keys := make([]string, 0, len(object))
+		for k := range object {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if property, ok := s.propertyOf(k); ok {
+				property.validate(category, joinKeyPath(path, k), object[k], errs)
+			} else if s.AdditionalProperties != nil {
+				s.AdditionalProperties.validate(category, joinKeyPath(path, k), object[k], errs)
+			}
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		sub.validate(category, path, value, errs)
+	}
+
+	if s.If != nil && s.Then != nil {
+		var ifErrs []error
+		s.If.validate(category, path, value, &ifErrs)
+		if len(ifErrs) == 0 {
+			s.Then.validate(category, path, value, errs)
+		}
+	}
+}
+
+// propertyOf - returns the schema of the property, the config keys are lower-cased by viper
+func (s *Schema) propertyOf(name string) (*Schema, bool) {
+	if property, ok := s.Properties[name]; ok {
+		return property, true
+	}
+	for k, property := range s.Properties {
+		if strings.ToLower(k) == name {
+			return property, true
+		}
+	}
+	return nil, false
+}
+
+// schemaOfType - build the schema of the go type based on its `json`, `default` and `validate` tags
+func schemaOfType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == durationType {
+		return &Schema{Type: []string{"integer", "string"}}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOfType(t.Elem())}
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		addStructProperties(s, t)
+		return s
+	}
+	return &Schema{}
+}
+
+// addStructProperties - add the fields of the struct as the properties of the schema
+func addStructProperties(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+
+		// the fields of the embedded structs are promoted to the parent
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			addStructProperties(s, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := schemaOfType(field.Type)
+		if defaultValue, ok := field.Tag.Lookup("default"); ok {
+			property.Default = schemaDefaultValue(field.Type, defaultValue)
+		}
+
+		required := applyValidateRules(property, field.Type, field.Tag.Get("validate"))
+		if required && property.Default == nil {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = property
+	}
+}
+
+// schemaDefaultValue - convert the value of the `default` tag to the typed value of the field
+func schemaDefaultValue(t reflect.Type, value string) interface{} {
+	if t == durationType {
+		return value
+	}
+
+	v := reflect.New(t).Elem()
+	if err := setDefaultValue(v, value); err != nil {
+		return value
+	}
+	return v.Interface()
+}
+
+// applyValidateRules - map the rules of the `validate` tag to the schema keywords,
+// it returns true if the field is required
+func applyValidateRules(s *Schema, t reflect.Type, rules string) bool {
+	if rules == "" {
+		return false
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	required := false
+	parts := strings.Split(rules, ",")
+	for i, rule := range parts {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			// the rest of the rules belong to the items
+			if s.Items != nil {
+				applyValidateRules(s.Items, t.Elem(), strings.Join(parts[i+1:], ","))
+			} else if s.AdditionalProperties != nil {
+				applyValidateRules(s.AdditionalProperties, t.Elem(), strings.Join(parts[i+1:], ","))
+			}
+			return required
+		case "required":
+			required = true
+		case "oneof":
+			for _, item := range strings.Fields(param) {
+				if n, err := strconv.ParseFloat(item, 64); err == nil && t.Kind() != reflect.String {
+					s.Enum = append(s.Enum, n)
+				} else {
+					s.Enum = append(s.Enum, item)
+				}
+			}
+		case "min", "max", "gte", "lte":
+			n, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+
+			switch t.Kind() {
+			case reflect.String:
+				if name == "min" || name == "gte" {
+					length := int(n)
+					s.MinLength = &length
+				}
+			case reflect.Slice, reflect.Array, reflect.Map:
+				if name == "min" || name == "gte" {
+					length := int(n)
+					s.MinItems = &length
+				}
+			default:
+				if name == "min" || name == "gte" {
+					s.Minimum = &n
+				} else {
+					s.Maximum = &n
+				}
+			}
+		}
+	}
+	return required
+}
+
+// MARK: Public Functions
+
+// SchemaOf - build the schema of the config struct from its `json`, `default` and `validate` tags
+func SchemaOf(v interface{}) *Schema {
+	return schemaOfType(reflect.TypeOf(v))
+}
+
+// ModuleSchema - make the root schema of the config module,
+// every module may also have the `env` list that is bound to the environment variables
+func ModuleSchema(name string, root *Schema) *Schema {
+	root.Schema = SchemaDraft
+	root.Title = name
+	if root.Properties == nil {
+		root.Properties = make(map[string]*Schema)
+	}
+	if _, ok := root.Properties["env"]; !ok {
+		root.Properties["env"] = &Schema{Type: "array", Items: &Schema{Type: "string"}}
+	}
+	return root
+}
+
+// Validate - validate the value against the schema and returns all errors with the exact key path of them
+func (s *Schema) Validate(category string, value interface{}) []error {
+	var errs []error
+	s.validate(category, "", value, &errs)
+	return errs
+}
+
+// ValidateModules - load every module that is listed in `base.json` of the mode from all config layers
+// and validate it against its schema
+func ValidateModules(configBasePath string, configMode string, schemas map[string]*Schema) ([]ModuleValidation, error) {
+	base := viper.New()
+	base.AddConfigPath(fmt.Sprintf("%s/configs/%s/", configBasePath, configMode))
+	base.SetConfigName("base")
+	err := base.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var modules []baseModule
+	err = decodeValue("base", "modules", normalizeValue(base.Get("modules")), &modules)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ModuleValidation
+	for _, module := range modules {
+		item := ModuleValidation{Name: module.Name, Type: module.Type}
+		schema, hasSchema := schemas[module.Name]
+		item.HasSchema = hasSchema
+
+		if module.Type == "remote" {
+			item.IsSkipped = true
+			item.SkipReason = "remote modules are loaded from the config server"
+			result = append(result, item)
+			continue
+		}
+
+		w := &ViperWrapper{
+			ConfigLayers: defaultConfigLayers(configBasePath, configMode),
+			ConfigName:   module.Name,
+		}
+		err := w.Load()
+		if err != nil {
+			item.LoadingError = err
+			result = append(result, item)
+			continue
+		}
+
+		if hasSchema {
+			item.Errors = schema.Validate(module.Name, w.Instance.AllSettings())
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSchemaOf(t *testing.T) {
+	s := SchemaOf(testDecodeServer{})
+
+	if !reflect.DeepEqual(s.Required, []string{"host"}) {
+		t.Errorf("Required fields of the schema --> Expected: %v, but got %v", []string{"host"}, s.Required)
+	}
+
+	port := s.Properties["port"]
+	if port.Type != "integer" || port.Default != 3306 || *port.Minimum != 1 || *port.Maximum != 65535 {
+		t.Errorf("Schema of the `port` --> got unexpected value %+v", port)
+	}
+
+	expectedEnum := []interface{}{"tcp", "udp"}
+	if !reflect.DeepEqual(s.Properties["protocol"].Enum, expectedEnum) {
+		t.Errorf("Enum of the `protocol` --> Expected: %v, but got %v", expectedEnum, s.Properties["protocol"].Enum)
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	s := &Schema{
+		Type:     "object",
+		Required: []string{"connections"},
+		Properties: map[string]*Schema{
+			"connections": {Type: "array", Items: &Schema{Type: "string"}},
+		},
+		AdditionalProperties: SchemaOf(testDecodeServer{}),
+	}
+
+	value := map[string]interface{}{
+		"server1": map[string]interface{}{"host": "h", "port": float64(3306)},
+		"server2": map[string]interface{}{"port": "3306", "protocol": "http"},
+		"servers": []interface{}{"a", float64(1)},
+	}
+
+	expectedKeys := []string{"connections", "server2.host", "server2.port", "server2.protocol"}
+
+	var actualKeys []string
+	for _, err := range s.Validate("db", value) {
+		schemaErr, ok := err.(*SchemaErr)
+		if !ok {
+			t.Errorf("Validating the value --> Expected: %T, but got %v", &SchemaErr{}, err)
+			continue
+		}
+		actualKeys = append(actualKeys, schemaErr.Key)
+	}
+
+	// `servers` is not a server config, so it fails on its type
+	expectedKeys = append(expectedKeys, "servers")
+	if !reflect.DeepEqual(expectedKeys, actualKeys) {
+		t.Errorf("Validating the value --> Expected: %v, but got %v", expectedKeys, actualKeys)
+	}
+}
+
+func TestValidateModules(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(dir, "configs", "dev"), os.ModePerm)
+	_ = os.WriteFile(filepath.Join(dir, "configs", "dev", "base.json"), []byte(`{
+		"name": "test",
+		"modules": [{"name": "db", "type": "local"}, {"name": "server", "type": "remote"}, {"name": "http", "type": "local"}]
+	}`), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "configs", "db.json"), []byte(`{"server1": {"host": "h", "port": "a"}}`), 0o644)
+
+	schemas := map[string]*Schema{
+		"db": {AdditionalProperties: SchemaOf(testDecodeServer{})},
+	}
+	result, err := ValidateModules(dir, "dev", schemas)
+	if err != nil {
+		t.Errorf("Validating the modules --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	if len(result) != 3 {
+		t.Errorf("Validating the modules --> Expected: %v results, but got %v", 3, len(result))
+		return
+	}
+	if len(result[0].Errors) != 1 || result[0].Errors[0].(*SchemaErr).Key != "server1.port" {
+		t.Errorf("Validating the `db` module --> Expected error on %v, but got %v", "server1.port", result[0].Errors)
+	}
+	if !result[1].IsSkipped {
+		t.Errorf("Validating the `server` remote module --> Expected to be skipped, but got %+v", result[1])
+	}
+	if result[2].LoadingError == nil {
+		t.Errorf("Validating the `http` module without file --> Expected an error, but got %v", nil)
+	}
+}
@@ -0,0 +1,285 @@
+package config
+
+// Imports needed list
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MARK: Constants
+
+// Names of the built-in secret providers
+const (
+	FileSecretProvider = "file"
+	EnvSecretProvider  = "env"
+)
+
+// RedactedValue - the placeholder that is printed instead of a resolved secret
+const RedactedValue = "******"
+
+// minSecretLength - the secrets shorter than it are not resolved, they cannot be redacted from the logs safely,
+// e.g. the secret `1` would redact every `1` of the logs
+const minSecretLength = 6
+
+// MARK: Variables
+
+var (
+	// secretRefRegexp - matches `${secret:<provider>:<ref>}` and `${env:<name>}`
+	secretRefRegexp = regexp.MustCompile(`\$\{(?:secret:([A-Za-z0-9_\-]+):|(env):)([^}]+)}`)
+
+	secretProvidersLock sync.RWMutex
+	secretProviders     = map[string]SecretProvider{
+		FileSecretProvider: SecretProviderFunc(fileSecretResolve),
+		EnvSecretProvider:  SecretProviderFunc(envSecretResolve),
+	}
+
+	secretValuesLock sync.RWMutex
+	secretValues     = map[string]struct{}{}
+)
+
+// MARK: SecretProvider
+
+// SecretProvider - the interface that every secret backend (file, env, vault, kv, ...) must implement
+type SecretProvider interface {
+	// Resolve - returns the secret value of the reference, the reference is the part after `<provider>:`
+	Resolve(ref string) (string, error)
+}
+
+// SecretProviderFunc - an adapter to use an ordinary function as a SecretProvider
+type SecretProviderFunc func(ref string) (string, error)
+
+// Resolve - satisfying SecretProvider interface
+func (f SecretProviderFunc) Resolve(ref string) (string, error) {
+	return f(ref)
+}
+
+// MARK: Private Functions
+
+// fileSecretResolve - read the secret from the file, e.g. `${secret:file:/run/secrets/db_pass}`
+func fileSecretResolve(ref string) (string, error) {
+	data, err := os.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// envSecretResolve - read the secret from the environment variable, e.g. `${env:DB_PASS}`
+func envSecretResolve(ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %q is not set", ref)
+	}
+	return value, nil
+}
+
+// getSecretProvider - returns the registered provider by its name
+func getSecretProvider(name string) (SecretProvider, bool) {
+	secretProvidersLock.RLock()
+	defer secretProvidersLock.RUnlock()
+
+	provider, ok := secretProviders[name]
+	return provider, ok
+}
+
+// addSecretValue - remember the resolved value to redact it from the outputs
+func addSecretValue(value string) {
+	if value == "" {
+		return
+	}
+
+	secretValuesLock.Lock()
+	secretValues[value] = struct{}{}
+	secretValuesLock.Unlock()
+}
+
+// resolveSecretString - replace all secret references of the string with their values
+func resolveSecretString(value string) (string, bool, error) {
+	if !strings.Contains(value, "${") {
+		return value, false, nil
+	}
+
+	var resolveErr error
+	changed := false
+	result := secretRefRegexp.ReplaceAllStringFunc(value, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+
+		parts := secretRefRegexp.FindStringSubmatch(match)
+		name := parts[1]
+		if name == "" {
+			name = parts[2]
+		}
+
+		provider, ok := getSecretProvider(name)
+		if !ok {
+			resolveErr = fmt.Errorf("secret provider %q is not registered", name)
+			return match
+		}
+
+		secret, err := provider.Resolve(parts[3])
+		if err != nil {
+			resolveErr = fmt.Errorf("cannot resolve %q: %v", match, err)
+			return match
+		}
+
+		if len(secret) < minSecretLength {
+			resolveErr = fmt.Errorf("the secret of %q is shorter than %d characters, so it cannot be redacted from the logs", match, minSecretLength)
+			return match
+		}
+
+		addSecretValue(secret)
+		changed = true
+		return secret
+	})
+	if resolveErr != nil {
+		return value, false, resolveErr
+	}
+
+	return result, changed, nil
+}
+
+// resolveSecretValue - walk the value (string, slice or map) and resolve all secret references in it
+func resolveSecretValue(value interface{}) (interface{}, bool, error) {
+	switch v := value.(type) {
+	case string:
+		return resolveSecretString(v)
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		changed := false
+		for i, item := range v {
+			resolved, itemChanged, err := resolveSecretValue(item)
+			if err != nil {
+				return value, false, err
+			}
+			result[i] = resolved
+			changed = changed || itemChanged
+		}
+		return result, changed, nil
+	case []string:
+		result := make([]string, len(v))
+		changed := false
+		for i, item := range v {
+			resolved, itemChanged, err := resolveSecretString(item)
+			if err != nil {
+				return value, false, err
+			}
+			result[i] = resolved
+			changed = changed || itemChanged
+		}
+		return result, changed, nil
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		changed := false
+		for k, item := range v {
+			resolved, itemChanged, err := resolveSecretValue(item)
+			if err != nil {
+				return value, false, err
+			}
+			result[k] = resolved
+			changed = changed || itemChanged
+		}
+		return result, changed, nil
+	}
+	return value, false, nil
+}
+
+// setNestedValue - set the value in the nested map by the dotted key path
+func setNestedValue(m map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(strings.ToLower(key), ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := m[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[part] = next
+		}
+		m = next
+	}
+	m[parts[len(parts)-1]] = value
+}
+
+// copySettings - deep copy the nested settings map
+func copySettings(settings map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		if nested, ok := v.(map[string]interface{}); ok {
+			result[k] = copySettings(nested)
+		} else {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// MARK: Public Functions
+
+// RegisterSecretProvider - register the provider by the name that is used in `${secret:<name>:<ref>}`,
+// e.g. a vault client registered as `kv` resolves `${secret:kv:path#key}`
+func RegisterSecretProvider(name string, provider SecretProvider) error {
+	if name == "" || provider == nil {
+		return errors.New("the name and the provider of the secret must be set")
+	}
+
+	secretProvidersLock.Lock()
+	defer secretProvidersLock.Unlock()
+
+	secretProviders[name] = provider
+	return nil
+}
+
+// IsSecretReference - check whether the value contains any secret reference
+func IsSecretReference(value string) bool {
+	return secretRefRegexp.MatchString(value)
+}
+
+// RedactSecrets - replace all resolved secret values that exist in the text with RedactedValue
+func RedactSecrets(text string) string {
+	secretValuesLock.RLock()
+	defer secretValuesLock.RUnlock()
+
+	if len(secretValues) == 0 {
+		return text
+	}
+
+	values := make([]string, 0, len(secretValues))
+	for v := range secretValues {
+		if strings.Contains(text, v) {
+			values = append(values, v)
+		}
+	}
+	// replace the longest values first, so a secret that contains another one is redacted entirely
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	for _, v := range values {
+		text = strings.ReplaceAll(text, v, RedactedValue)
+	}
+	return text
+}
+
+// RedactSecretValue - returns the value itself if it has no resolved secret in it,
+// otherwise returns its redacted string representation
+func RedactSecretValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	secretValuesLock.RLock()
+	empty := len(secretValues) == 0
+	secretValuesLock.RUnlock()
+	if empty {
+		return value
+	}
+
+	text := fmt.Sprintf("%v", value)
+	redacted := RedactSecrets(text)
+	if redacted == text {
+		return value
+	}
+	return redacted
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveSecretString(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "db_pass")
+	_ = os.WriteFile(secretFile, []byte("file-pass\n"), 0o600)
+	t.Setenv("ZHYCAN_TEST_DB_PASS", "env-pass")
+
+	_ = RegisterSecretProvider("kv", SecretProviderFunc(func(ref string) (string, error) {
+		if ref == "db/prod#password" {
+			return "kv-pass", nil
+		}
+		return "", errors.New("not found")
+	}))
+
+	expectedValues := map[string]string{
+		"${secret:file:" + secretFile + "}":    "file-pass",
+		"${env:ZHYCAN_TEST_DB_PASS}":           "env-pass",
+		"${secret:env:ZHYCAN_TEST_DB_PASS}":    "env-pass",
+		"${secret:kv:db/prod#password}":        "kv-pass",
+		"user:${env:ZHYCAN_TEST_DB_PASS}@host": "user:env-pass@host",
+		"plain-value":                          "plain-value",
+	}
+
+	for input, expected := range expectedValues {
+		actual, _, err := resolveSecretString(input)
+		if err != nil {
+			t.Errorf("Resolving %v --> Expected: %v, but got %v", input, nil, err)
+			continue
+		}
+		if actual != expected {
+			t.Errorf("Resolving %v --> Expected: %v, but got %v", input, expected, actual)
+		}
+	}
+
+	for _, input := range []string{"${secret:unknown:a}", "${env:ZHYCAN_TEST_NOT_EXIST}", "${secret:kv:not/exist#key}"} {
+		_, _, err := resolveSecretString(input)
+		if err == nil {
+			t.Errorf("Resolving %v --> Expected an error, but got %v", input, err)
+		}
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	t.Setenv("ZHYCAN_TEST_REDACT", "redact-me-please")
+	_, _, _ = resolveSecretString("${env:ZHYCAN_TEST_REDACT}")
+
+	expected := "dsn: user:" + RedactedValue + "@tcp(127.0.0.1)"
+	actual := RedactSecrets("dsn: user:redact-me-please@tcp(127.0.0.1)")
+	if actual != expected {
+		t.Errorf("Redacting secrets --> Expected: %v, but got %v", expected, actual)
+	}
+
+	if v := RedactSecretValue(12); v != 12 {
+		t.Errorf("Redacting a value without secret --> Expected: %v, but got %v", 12, v)
+	}
+}
+
+func TestResolveShortSecret(t *testing.T) {
+	t.Setenv("ZHYCAN_TEST_SHORT_SECRET", "pass")
+
+	_, _, err := resolveSecretString("user:${env:ZHYCAN_TEST_SHORT_SECRET}@host")
+	if err == nil {
+		t.Errorf("Resolving a short secret --> Expected an error, but got %v", err)
+	}
+
+	expected := "dsn: user:pass@host"
+	actual := RedactSecrets(expected)
+	if actual != expected {
+		t.Errorf("Redacting the text of a not resolved secret --> Expected: %v, but got %v", expected, actual)
+	}
+}
+
+func TestWrapperSecretReference(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("ZHYCAN_TEST_WRAPPER_PASS", "wrapper-pass")
+
+	content := `{"server1": {"host": "127.0.0.1", "password": "${env:ZHYCAN_TEST_WRAPPER_PASS}"}}`
+	_ = os.WriteFile(filepath.Join(dataDir, "db.json"), []byte(content), 0o644)
+
+	w := &ViperWrapper{ConfigPath: []string{dataDir}, ConfigName: "db"}
+	err := w.Load()
+	if err != nil {
+		t.Errorf("Loading the wrapper with secret --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	actual, _ := w.Get("server1.password", false)
+	if actual != "wrapper-pass" {
+		t.Errorf("Get the secret value --> Expected: %v, but got %v", "wrapper-pass", actual)
+	}
+	if !w.IsSecretKey("server1.password") || w.IsSecretKey("server1.host") {
+		t.Errorf("Checking the secret keys --> Expected: %v, but got %v", []string{"server1.password"}, w.secretKeys)
+	}
+
+	err = w.Set("server1.port", 3306, false)
+	if err != nil {
+		t.Errorf("Set the value --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	written, _ := os.ReadFile(filepath.Join(dataDir, "db.json"))
+	if strings.Contains(string(written), "wrapper-pass") || !strings.Contains(string(written), "${env:ZHYCAN_TEST_WRAPPER_PASS}") {
+		t.Errorf("Writing the config --> Expected the secret reference, but got %v", string(written))
+	}
+}
@@ -0,0 +1,432 @@
+package config
+
+// Imports needed list
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MARK: Constants
+
+// DefaultSnapshotCount - the number of the last snapshots that are kept for every module
+const DefaultSnapshotCount = 10
+
+// DefaultSnapshotDir - the directory next to the config file that the snapshots are kept in
+const DefaultSnapshotDir = ".snapshots"
+
+// MARK: Tx
+
+// txOperation - one staged change of the transaction
+type txOperation struct {
+	key      string
+	value    interface{}
+	isDelete bool
+}
+
+// Tx - the transaction of the config module, all staged changes are written to the file together or not at all
+type Tx struct {
+	category   string
+	operations []txOperation
+	settings   map[string]interface{}
+}
+
+// Set - stage the value of the key, the value may be a secret reference
+func (tx *Tx) Set(key string, value interface{}) error {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if key == "" {
+		return NewKeyNotExistErr(key, tx.category, fmt.Errorf("empty key"))
+	}
+
+	_, _, err := resolveSecretValue(value)
+	if err != nil {
+		return NewSecretResolveErr(tx.category, key, err)
+	}
+
+	tx.operations = append(tx.operations, txOperation{key: key, value: value})
+	return nil
+}
+
+// Delete - stage the removal of the key from the config file, the value of the lower layers is used again if exists
+func (tx *Tx) Delete(key string) error {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if key == "" {
+		return NewKeyNotExistErr(key, tx.category, fmt.Errorf("empty key"))
+	}
+
+	tx.operations = append(tx.operations, txOperation{key: key, isDelete: true})
+	return nil
+}
+
+// Get - returns the value of the key with the staged changes applied.
+// Secret references are returned as they are, not the resolved secrets.
+func (tx *Tx) Get(key string) (interface{}, bool) {
+	settings := copySettings(tx.settings)
+	tx.apply(settings)
+	return getNestedValue(settings, key)
+}
+
+// apply - apply the staged changes on the settings in order
+func (tx *Tx) apply(settings map[string]interface{}) {
+	for _, op := range tx.operations {
+		if op.isDelete {
+			deleteNestedValue(settings, op.key)
+		} else {
+			setNestedValue(settings, op.key, op.value)
+		}
+	}
+}
+
+// MARK: Snapshot
+
+// Snapshot - one saved version of the config file of the module
+type Snapshot struct {
+	Version int       `json:"version"`
+	Time    time.Time `json:"time"`
+	File    string    `json:"file"`
+}
+
+// MARK: Private Functions
+
+// getNestedValue - get the value from the nested map by the dotted key path
+func getNestedValue(m map[string]interface{}, key string) (interface{}, bool) {
+	parts := strings.Split(strings.ToLower(key), ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := m[part].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		m = next
+	}
+	value, ok := m[parts[len(parts)-1]]
+	return value, ok
+}
+
+// deleteNestedValue - remove the value from the nested map by the dotted key path
+func deleteNestedValue(m map[string]interface{}, key string) {
+	parts := strings.Split(strings.ToLower(key), ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := m[part].(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = next
+	}
+	delete(m, parts[len(parts)-1])
+}
+
+// renameAtomic - flush the temp file and move it over the file, so the readers see the old or the new content
+func renameAtomic(tmp string, file string) error {
+	f, err := os.OpenFile(tmp, os.O_RDWR, 0)
+	if err == nil {
+		err = f.Sync()
+		_ = f.Close()
+	}
+	if err == nil {
+		if info, statErr := os.Stat(file); statErr == nil {
+			err = os.Chmod(tmp, info.Mode().Perm())
+		}
+	}
+	if err == nil {
+		err = os.Rename(tmp, file)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+	}
+	return err
+}
+
+// writeFileAtomic - write the data to a temp file in the same directory and rename it to the file
+func writeFileAtomic(file string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return renameAtomic(tmp, file)
+}
+
+// writeSettingsAtomic - write the settings in the format of the file by a temp file and rename it to the file
+func writeSettingsAtomic(file string, settings map[string]interface{}) error {
+	ext := filepath.Ext(file)
+	name := strings.TrimSuffix(filepath.Base(file), ext)
+	// the temp file keeps the extension, so viper writes it in the same format
+	tmp := filepath.Join(filepath.Dir(file), fmt.Sprintf(".%s.tmp-%d%s", name, time.Now().UnixNano(), ext))
+
+	writer := viper.New()
+	err := writer.MergeConfigMap(settings)
+	if err != nil {
+		return err
+	}
+	err = writer.WriteConfigAs(tmp)
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return renameAtomic(tmp, file)
+}
+
+// MARK: Private Methods
+
+// configFile - returns the file that the changes of the module are written to
+func (w *ViperWrapper) configFile() (string, error) {
+	if w.ConfigResourcePlace == "remote" {
+		return "", fmt.Errorf("the remote config is read-only")
+	}
+	if w.ConfigFS != nil {
+		return "", fmt.Errorf("the config file system is read-only")
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.Instance == nil || w.Instance.ConfigFileUsed() == "" {
+		return "", fmt.Errorf("the config is not loaded from a file")
+	}
+	return w.Instance.ConfigFileUsed(), nil
+}
+
+// snapshotDir - returns the directory of the snapshots of the config file
+func (w *ViperWrapper) snapshotDir(file string) string {
+	if w.SnapshotPath != "" {
+		return w.SnapshotPath
+	}
+	return filepath.Join(filepath.Dir(file), DefaultSnapshotDir)
+}
+
+// listSnapshots - returns the snapshots of the config file sorted by version
+func (w *ViperWrapper) listSnapshots(file string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(w.snapshotDir(file))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Snapshot
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		// the snapshot files are named as `<name>.<version>.<ext>`
+		parts := strings.Split(entry.Name(), ".")
+		if len(parts) != 3 || parts[0] != w.ConfigName {
+			continue
+		}
+		version, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		result = append(result, Snapshot{
+			Version: version,
+			Time:    info.ModTime(),
+			File:    filepath.Join(w.snapshotDir(file), entry.Name()),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+// saveSnapshot - save the content as the next version and remove the snapshots more than the limit
+func (w *ViperWrapper) saveSnapshot(file string, data []byte) (Snapshot, error) {
+	snapshots, err := w.listSnapshots(file)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	version := 1
+	if len(snapshots) > 0 {
+		version = snapshots[len(snapshots)-1].Version + 1
+	}
+
+	dir := w.snapshotDir(file)
+	err = os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		Version: version,
+		Time:    time.Now(),
+		File:    filepath.Join(dir, fmt.Sprintf("%s.%d%s", w.ConfigName, version, filepath.Ext(file))),
+	}
+	err = writeFileAtomic(snapshot.File, data)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	limit := w.SnapshotCount
+	if limit <= 0 {
+		limit = DefaultSnapshotCount
+	}
+	snapshots = append(snapshots, snapshot)
+	for len(snapshots) > limit {
+		_ = os.Remove(snapshots[0].File)
+		snapshots = snapshots[1:]
+	}
+	return snapshot, nil
+}
+
+// commit - replace the config file by the write function, load it and notify the subscribers once.
+// If the new content cannot be loaded, the previous content is restored.
+func (w *ViperWrapper) commit(file string, write func() error) error {
+	previousData, err := os.ReadFile(file)
+	if err != nil {
+		return NewUpdateErr(w.ConfigName, err)
+	}
+
+	snapshots, err := w.listSnapshots(file)
+	if err != nil {
+		return NewUpdateErr(w.ConfigName, err)
+	}
+	if len(snapshots) == 0 {
+		// keep the content before the first change, so it can be rolled back to
+		_, err = w.saveSnapshot(file, previousData)
+		if err != nil {
+			return NewUpdateErr(w.ConfigName, err)
+		}
+	}
+
+	previous := w.settings()
+	err = write()
+	if err != nil {
+		return NewUpdateErr(w.ConfigName, err)
+	}
+
+	err = w.Load()
+	if err != nil {
+		if restoreErr := writeFileAtomic(file, previousData); restoreErr == nil {
+			_ = w.Load()
+		}
+		return NewUpdateErr(w.ConfigName, err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err == nil {
+		_, err = w.saveSnapshot(file, data)
+	}
+	if err != nil {
+		log.Println(w.ConfigName, "Cannot save config snapshot: ", err)
+	}
+
+	w.notifyChange(diffSettings(w.ConfigName, previous, w.settings()))
+	return nil
+}
+
+// MARK: Public Methods
+
+// Update - apply all changes of the function to the config file at once.
+// If the function returns an error nothing is written; the subscribers are notified once per committed transaction.
+func (w *ViperWrapper) Update(fn func(tx *Tx) error) error {
+	w.wg.Wait()
+	return w.update(fn)
+}
+
+// update - see Update, it does not wait for the loading
+func (w *ViperWrapper) update(fn func(tx *Tx) error) error {
+	if w.ConfigData != nil {
+		return w.updateMemory(fn)
+	}
+
+	file, err := w.configFile()
+	if err != nil {
+		return NewUpdateErr(w.ConfigName, err)
+	}
+
+	w.txLock.Lock()
+	defer w.txLock.Unlock()
+
+	w.lock.Lock()
+	tx := &Tx{category: w.ConfigName, settings: copySettings(w.rawSettings)}
+	w.lock.Unlock()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+	if len(tx.operations) == 0 {
+		return nil
+	}
+
+	// just the file with the highest priority is changed, the other layers are kept as they are
+	fileInstance := viper.New()
+	fileInstance.SetConfigFile(file)
+	err = fileInstance.ReadInConfig()
+	if err != nil {
+		return NewUpdateErr(w.ConfigName, err)
+	}
+	settings := normalizeFileSettings(file, fileInstance.AllSettings())
+	tx.apply(settings)
+
+	return w.commit(file, func() error {
+		return writeSettingsAtomic(file, settings)
+	})
+}
+
+// Snapshots - returns the saved versions of the config file, from the oldest to the newest
+func (w *ViperWrapper) Snapshots() ([]Snapshot, error) {
+	w.wg.Wait()
+
+	file, err := w.configFile()
+	if err != nil {
+		return nil, NewUpdateErr(w.ConfigName, err)
+	}
+	return w.listSnapshots(file)
+}
+
+// Rollback - restore the config file to the content of the version, the rollback itself is saved as a new version
+func (w *ViperWrapper) Rollback(version int) error {
+	w.wg.Wait()
+
+	file, err := w.configFile()
+	if err != nil {
+		return NewUpdateErr(w.ConfigName, err)
+	}
+
+	w.txLock.Lock()
+	defer w.txLock.Unlock()
+
+	snapshots, err := w.listSnapshots(file)
+	if err != nil {
+		return NewUpdateErr(w.ConfigName, err)
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Version != version {
+			continue
+		}
+
+		data, err := os.ReadFile(snapshot.File)
+		if err != nil {
+			return NewUpdateErr(w.ConfigName, err)
+		}
+		return w.commit(file, func() error {
+			return writeFileAtomic(file, data)
+		})
+	}
+
+	return NewSnapshotNotExistErr(w.ConfigName, version)
+}
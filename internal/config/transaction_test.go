@@ -0,0 +1,180 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func createTxWrapper(t *testing.T) (*ViperWrapper, string) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "db.json"), []byte(`{"server1": {"host": "127.0.0.1", "port": 3306}, "connections": ["server1"]}`), 0644)
+
+	w := &ViperWrapper{ConfigPath: []string{dir}, ConfigName: "db", ChangeDebounce: 50 * time.Millisecond}
+	err := w.Load()
+	if err != nil {
+		t.Fatalf("Loading the wrapper --> Expected: %v, but got %v", nil, err)
+	}
+	return w, dir
+}
+
+func TestWrapperUpdate(t *testing.T) {
+	w, dir := createTxWrapper(t)
+
+	events := make(chan ChangeEvent, 10)
+	w.Subscribe("", func(event ChangeEvent) {
+		events <- event
+	})
+	defer w.fileWatcher.Close()
+
+	err := w.Update(func(tx *Tx) error {
+		_ = tx.Set("server1.host", "10.0.0.1")
+		_ = tx.Set("server1.port", 3307)
+		_ = tx.Delete("connections")
+
+		if v, _ := tx.Get("server1.port"); v != 3307 {
+			t.Errorf("Get the staged value --> Expected: %v, but got %v", 3307, v)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Updating the config --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	if v, _ := w.Get("server1.host", false); v != "10.0.0.1" {
+		t.Errorf("Value after the update --> Expected: %v, but got %v", "10.0.0.1", v)
+	}
+
+	reloaded, _ := createWrapper(dir, "db", "")
+	expected := map[string]interface{}{"server1": map[string]interface{}{"host": "10.0.0.1", "port": float64(3307)}}
+	if !reflect.DeepEqual(expected, reloaded.Instance.AllSettings()) {
+		t.Errorf("Content of the file --> Expected: %v, but got %v", expected, reloaded.Instance.AllSettings())
+	}
+
+	select {
+	case event := <-events:
+		if len(event.Changes) != 3 {
+			t.Errorf("Changes of the transaction --> Expected: %v, but got %v", 3, event.Changes)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Committing the transaction --> Expected the listener to be called, but it is not")
+	}
+
+	// the file event of the rename must not notify again
+	select {
+	case event := <-events:
+		t.Errorf("Notification of the transaction --> Expected just one event, but got another %v", event)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, ".db*"))
+	if len(matches) != 0 {
+		t.Errorf("Temp files of the transaction --> Expected: %v, but got %v", 0, matches)
+	}
+}
+
+func TestWrapperUpdateFailure(t *testing.T) {
+	w, dir := createTxWrapper(t)
+	before, _ := os.ReadFile(filepath.Join(dir, "db.json"))
+
+	expectedErr := errors.New("failed")
+	err := w.Update(func(tx *Tx) error {
+		_ = tx.Set("server1.host", "10.0.0.1")
+		return expectedErr
+	})
+	if err != expectedErr {
+		t.Errorf("Error of the transaction --> Expected: %v, but got %v", expectedErr, err)
+	}
+
+	err = w.Update(func(tx *Tx) error {
+		_ = tx.Set("server1.host", "10.0.0.1")
+		return tx.Set("server1.password", "${secret:not_exist:password}")
+	})
+	if _, ok := err.(*SecretResolveErr); !ok {
+		t.Errorf("Setting an unresolvable secret --> Expected: %T, but got %v", &SecretResolveErr{}, err)
+	}
+
+	after, _ := os.ReadFile(filepath.Join(dir, "db.json"))
+	if string(before) != string(after) {
+		t.Errorf("Content of the file after the failure --> Expected: %v, but got %v", string(before), string(after))
+	}
+	if v, _ := w.Get("server1.host", false); v != "127.0.0.1" {
+		t.Errorf("Value after the failure --> Expected: %v, but got %v", "127.0.0.1", v)
+	}
+}
+
+func TestWrapperRollback(t *testing.T) {
+	w, _ := createTxWrapper(t)
+	w.SnapshotCount = 3
+
+	for _, port := range []int{3307, 3308, 3309} {
+		err := w.Update(func(tx *Tx) error {
+			return tx.Set("server1.port", port)
+		})
+		if err != nil {
+			t.Errorf("Updating the config --> Expected: %v, but got %v", nil, err)
+			return
+		}
+	}
+
+	snapshots, err := w.Snapshots()
+	if err != nil {
+		t.Errorf("Getting the snapshots --> Expected: %v, but got %v", nil, err)
+		return
+	}
+	var versions []int
+	for _, s := range snapshots {
+		versions = append(versions, s.Version)
+	}
+	// the original content is version 1, and every update adds a version
+	if !reflect.DeepEqual([]int{2, 3, 4}, versions) {
+		t.Errorf("Versions of the snapshots --> Expected: %v, but got %v", []int{2, 3, 4}, versions)
+	}
+
+	err = w.Rollback(2)
+	if err != nil {
+		t.Errorf("Rolling back the config --> Expected: %v, but got %v", nil, err)
+	}
+	if v, _ := w.Get("server1.port", false); v != float64(3307) {
+		t.Errorf("Value after the rollback --> Expected: %v, but got %v", 3307, v)
+	}
+
+	err = w.Rollback(1)
+	if _, ok := err.(*SnapshotNotExistErr); !ok {
+		t.Errorf("Rolling back to a removed version --> Expected: %T, but got %v", &SnapshotNotExistErr{}, err)
+	}
+}
+
+func TestWrapperUpdateLayered(t *testing.T) {
+	w, root := createLayeredWrapper(t)
+	err := w.Load()
+	if err != nil {
+		t.Errorf("Loading layered wrapper --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	err = w.Update(func(tx *Tx) error {
+		return tx.Set("server1.options.charset", "utf8mb4")
+	})
+	if err != nil {
+		t.Errorf("Updating the config --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	content, _ := os.ReadFile(filepath.Join(root, "shared", "db.json"))
+	if !reflect.DeepEqual(`{"server1": {"host": "127.0.0.1", "port": 3306, "options": {"charset": "utf8"}}, "connections": ["server1"]}`, string(content)) {
+		t.Errorf("Content of the lower layer --> Expected to be unchanged, but got %v", string(content))
+	}
+
+	source, _ := w.GetKeySource("server1.options.charset")
+	if source.Layer != LocalLayer {
+		t.Errorf("Source of the updated key --> Expected: %v, but got %v", LocalLayer, source.Layer)
+	}
+	if v, _ := w.Get("server1.port", false); v != float64(3307) {
+		t.Errorf("Value of the local layer --> Expected: %v, but got %v", 3307, v)
+	}
+}
@@ -2,43 +2,374 @@ package config
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
+	"io/fs"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 )
 
+// MARK: ConfigLayer
+
+// Names of the default layers - ordered from the lowest priority to the highest
+const (
+	SharedLayer = "shared"
+	ModeLayer   = "mode"
+	LocalLayer  = "local"
+	// EnvLayer - the source of the keys that are overridden by the env variables
+	EnvLayer = "env"
+	// MemoryLayer - the source of the keys of the modules that are built from the maps
+	MemoryLayer = "memory"
+)
+
+// ConfigLayer - one directory that the module config file can be read from
+type ConfigLayer struct {
+	Name string
+	Path string
+}
+
+// KeySource - the layer and the file that the effective value of a key came from
+type KeySource struct {
+	Layer string `json:"layer"`
+	File  string `json:"file"`
+	Env   string `json:"env,omitempty"`
+}
+
 // MARK: ViperWrapper
 
 // ViperWrapper object
 type ViperWrapper struct {
 	Instance            *viper.Viper
 	ConfigPath          []string
+	ConfigLayers        []ConfigLayer
 	ConfigName          string
 	ConfigEnvPrefix     string
 	ConfigResourcePlace string
+	ConfigFS            fs.FS
+	ConfigData          map[string]interface{}
+	AutomaticEnv        bool
+	ChangeDebounce      time.Duration
+	SnapshotPath        string
+	SnapshotCount       int
 	lastModified        time.Time
+	keySources          map[string]KeySource
+	rawSettings         map[string]interface{}
+	secretKeys          map[string]struct{}
+	envOverrides        []EnvOverride
+	changeCallbacks     []func() interface{}
+	changeListeners     []changeSubscription
+	fileWatcher         *fsnotify.Watcher
+	reloadTimer         *time.Timer
 	wg                  sync.WaitGroup
 	lock                sync.Mutex
+	txLock              sync.Mutex
+}
+
+// MARK: Private Methods
+
+// findLayerFile - returns the config file of the module inside the layer directory if it exists
+func (w *ViperWrapper) findLayerFile(layer ConfigLayer) string {
+	if w.ConfigFS != nil {
+		return findFSConfigFile(w.ConfigFS, layer.Path, w.ConfigName)
+	}
+
+	for _, ext := range viper.SupportedExts {
+		file := filepath.Join(layer.Path, w.ConfigName+"."+ext)
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file
+		}
+	}
+	return ""
+}
+
+// loadLayers - read all existing layer files and deep-merge them in order
+func (w *ViperWrapper) loadLayers() (*viper.Viper, map[string]KeySource, error) {
+	instance := viper.New()
+	sources := make(map[string]KeySource)
+
+	lastFile := ""
+	for _, layer := range w.ConfigLayers {
+		file := w.findLayerFile(layer)
+		if file == "" {
+			continue
+		}
+
+		layerInstance, err := w.readFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, key := range layerInstance.AllKeys() {
+			sources[key] = KeySource{Layer: layer.Name, File: file}
+		}
+
+		err = instance.MergeConfigMap(normalizeFileSettings(file, layerInstance.AllSettings()))
+		if err != nil {
+			return nil, nil, err
+		}
+		lastFile = file
+	}
+
+	if lastFile == "" {
+		var paths []string
+		for _, layer := range w.ConfigLayers {
+			paths = append(paths, layer.Path)
+		}
+		return nil, nil, NewCategoryNotExistErr(w.ConfigName, fmt.Errorf("not found in any layer: %v", paths))
+	}
+
+	// the file with the highest priority is the one that is written back
+	instance.SetConfigFile(lastFile)
+	return instance, sources, nil
+}
+
+// resolveSecrets - replace the secret references of the instance with the resolved values.
+// It returns the settings as they are in the file, so they can be written back without the secrets.
+func (w *ViperWrapper) resolveSecrets(instance *viper.Viper) (map[string]interface{}, map[string]struct{}, error) {
+	raw := instance.AllSettings()
+	secretKeys := make(map[string]struct{})
+
+	for _, key := range instance.AllKeys() {
+		resolved, changed, err := resolveSecretValue(instance.Get(key))
+		if err != nil {
+			return nil, nil, NewSecretResolveErr(w.ConfigName, key, err)
+		}
+		if changed {
+			instance.Set(key, resolved)
+			secretKeys[key] = struct{}{}
+		}
+	}
+
+	return raw, secretKeys, nil
+}
+
+// watchedDirs - returns the directories that the config files of the module can be in
+func (w *ViperWrapper) watchedDirs() []string {
+	var dirs []string
+	if len(w.ConfigLayers) > 0 {
+		for _, layer := range w.ConfigLayers {
+			dirs = append(dirs, layer.Path)
+		}
+	} else if w.Instance != nil && w.Instance.ConfigFileUsed() != "" {
+		dirs = append(dirs, filepath.Dir(w.Instance.ConfigFileUsed()))
+	} else {
+		dirs = append(dirs, w.ConfigPath...)
+	}
+	return dirs
+}
+
+// watchFiles - watch the directories of the config files and reload the config on every change.
+// Layered configs are watched on all layers, not only on the file in use.
+func (w *ViperWrapper) watchFiles() {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Println(w.ConfigName, "Cannot watch config files: ", err)
+		return
+	}
+
+	for _, dir := range w.watchedDirs() {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			_ = watcher.Add(dir)
+		}
+	}
+
+	w.lock.Lock()
+	w.fileWatcher = watcher
+	w.lock.Unlock()
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				base := filepath.Base(event.Name)
+				if strings.TrimSuffix(base, filepath.Ext(base)) != w.ConfigName {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+					continue
+				}
+
+				w.scheduleReload()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Println(w.ConfigName, "Config files watcher error: ", err)
+			}
+		}
+	}()
+}
+
+// scheduleReload - reload the config after the debounce time, every new file event postpones it
+func (w *ViperWrapper) scheduleReload() {
+	debounce := w.ChangeDebounce
+	if debounce <= 0 {
+		debounce = DefaultChangeDebounce
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.reloadTimer == nil {
+		w.reloadTimer = time.AfterFunc(debounce, w.reload)
+	} else {
+		w.reloadTimer.Reset(debounce)
+	}
+}
+
+// reload - load the config again and notify the subscribers of the changed keys
+func (w *ViperWrapper) reload() {
+	// the changes of a transaction are notified by the transaction itself
+	w.txLock.Lock()
+	defer w.txLock.Unlock()
+
+	previous := w.settings()
+	err := w.Load()
+	if err != nil {
+		log.Println(w.ConfigName, "Cannot reload config: ", err)
+		return
+	}
+
+	event := diffSettings(w.ConfigName, previous, w.settings())
+	if !event.IsEmpty() {
+		log.Println(w.ConfigName, "Config file changed: ", event.Roots())
+	}
+	w.notifyChange(event)
+}
+
+// settings - returns all settings of the current instance
+func (w *ViperWrapper) settings() map[string]interface{} {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.Instance == nil {
+		return map[string]interface{}{}
+	}
+	return w.Instance.AllSettings()
+}
+
+// notifyChange - call all registered change callbacks and the listeners of the changed key paths
+func (w *ViperWrapper) notifyChange(event ChangeEvent) {
+	if event.IsEmpty() {
+		return
+	}
+
+	w.lock.Lock()
+	callbacks := w.changeCallbacks
+	listeners := w.changeListeners
+	w.lock.Unlock()
+
+	for _, fn := range callbacks {
+		if fn != nil {
+			fn()
+		}
+	}
+
+	for _, item := range listeners {
+		filtered := event.Filter(item.keyPath)
+		if !filtered.IsEmpty() {
+			item.listener(filtered)
+		}
+	}
+}
+
+// startWatcher - start watching the config files once, remote configs are refreshed by the remote loader
+func (w *ViperWrapper) startWatcher() {
+	if w.ConfigResourcePlace == "remote" || w.isVirtual() {
+		return
+	}
+
+	w.lock.Lock()
+	isWatching := w.fileWatcher != nil
+	w.lock.Unlock()
+
+	if !isWatching {
+		w.watchFiles()
+	}
 }
 
 // MARK: Public Methods
 
-// Load - It creates new instance of Viper and load config file base on ConfigName
+// Load - It creates new instance of Viper and load config file base on ConfigName.
+// If `ConfigLayers` is set, the module file of every layer is deep-merged in order.
+// If `ConfigData` is set, the module is built from the map; if `ConfigFS` is set, the files are read from it.
 func (w *ViperWrapper) Load() error {
 	w.wg.Add(1)
 	defer w.wg.Done()
 
-	w.Instance = viper.New()
-	for _, path := range w.ConfigPath {
-		w.Instance.AddConfigPath(path)
+	var instance *viper.Viper
+	var sources map[string]KeySource
+	if w.ConfigData != nil {
+		var err error
+		instance, err = w.loadMemory()
+		if err != nil {
+			return err
+		}
+	} else if len(w.ConfigLayers) > 0 {
+		var err error
+		instance, sources, err = w.loadLayers()
+		if err != nil {
+			return err
+		}
+	} else if w.ConfigFS != nil {
+		var err error
+		instance, err = w.loadFSFile()
+		if err != nil {
+			return err
+		}
+	} else {
+		fileInstance := viper.New()
+		for _, path := range w.ConfigPath {
+			fileInstance.AddConfigPath(path)
+		}
+		fileInstance.SetConfigName(w.ConfigName)
+		err := fileInstance.ReadInConfig()
+		if err != nil {
+			return err
+		}
+
+		// every format is normalized to the same value types
+		file := fileInstance.ConfigFileUsed()
+		instance = viper.New()
+		instance.SetConfigFile(file)
+		err = instance.MergeConfigMap(normalizeFileSettings(file, fileInstance.AllSettings()))
+		if err != nil {
+			return err
+		}
 	}
-	w.Instance.SetConfigName(w.ConfigName)
-	err := w.Instance.ReadInConfig()
+
+	envOverrides, err := w.applyEnvOverrides(instance)
 	if err != nil {
 		return err
 	}
+	if len(envOverrides) > 0 && sources == nil {
+		sources = make(map[string]KeySource)
+	}
+	for _, item := range envOverrides {
+		sources[item.Key] = KeySource{Layer: EnvLayer, Env: item.Name}
+	}
+
+	raw, secretKeys, err := w.resolveSecrets(instance)
+	if err != nil {
+		return err
+	}
+
+	w.lock.Lock()
+	w.Instance = instance
+	w.keySources = sources
+	w.rawSettings = raw
+	w.secretKeys = secretKeys
+	w.envOverrides = envOverrides
+	w.lock.Unlock()
 
 	// Get env variables and bind them if exist in config file
 	env, exist := w.Get("env", true)
@@ -65,14 +396,37 @@ func (w *ViperWrapper) LoadFromRemote(data []byte) error {
 	w.wg.Add(1)
 	defer w.wg.Done()
 
-	if w.Instance == nil {
-		w.Instance = viper.New()
+	instance := viper.New()
+	instance.SetConfigType("json")
+	err := instance.ReadConfig(bytes.NewBuffer(data))
+	if err != nil {
+		return err
 	}
 
-	err := w.Instance.ReadConfig(bytes.NewBuffer(data))
+	envOverrides, err := w.applyEnvOverrides(instance)
 	if err != nil {
 		return err
 	}
+	sources := make(map[string]KeySource)
+	for _, item := range envOverrides {
+		sources[item.Key] = KeySource{Layer: EnvLayer, Env: item.Name}
+	}
+
+	raw, secretKeys, err := w.resolveSecrets(instance)
+	if err != nil {
+		return err
+	}
+
+	previous := w.settings()
+
+	w.lock.Lock()
+	w.Instance = instance
+	w.keySources = sources
+	w.rawSettings = raw
+	w.secretKeys = secretKeys
+	w.envOverrides = envOverrides
+	w.lock.Unlock()
+	w.lastModified = time.Now()
 
 	// Get env variables and bind them if exist in config file
 	env, exist := w.Get("env", true)
@@ -91,6 +445,15 @@ func (w *ViperWrapper) LoadFromRemote(data []byte) error {
 		}
 	}
 
+	// notify the subscribers just when the remote content is really changed
+	if len(previous) > 0 {
+		event := diffSettings(w.ConfigName, previous, w.settings())
+		if !event.IsEmpty() {
+			log.Println(w.ConfigName, "Remote config changed: ", event.Roots())
+		}
+		w.notifyChange(event)
+	}
+
 	return nil
 }
 
@@ -98,14 +461,27 @@ func (w *ViperWrapper) LoadFromRemote(data []byte) error {
 func (w *ViperWrapper) RegisterChangeCallback(fn func() interface{}) {
 	w.wg.Wait()
 
-	w.Instance.WatchConfig()
-	w.Instance.OnConfigChange(func(e fsnotify.Event) {
-		log.Println(w.ConfigName, "Config file changed: ", e.Name)
+	w.lock.Lock()
+	w.changeCallbacks = append(w.changeCallbacks, fn)
+	w.lock.Unlock()
 
-		if fn != nil {
-			fn()
-		}
-	})
+	w.startWatcher()
+}
+
+// Subscribe - call the listener with the changes of the key path (and the keys under it) whenever they change.
+// An empty key path subscribes to all keys of the module.
+func (w *ViperWrapper) Subscribe(keyPath string, listener ChangeListener) {
+	w.wg.Wait()
+
+	if listener == nil {
+		return
+	}
+
+	w.lock.Lock()
+	w.changeListeners = append(w.changeListeners, changeSubscription{keyPath: strings.ToLower(keyPath), listener: listener})
+	w.lock.Unlock()
+
+	w.startWatcher()
 }
 
 // Get method - returns value base on key
@@ -121,15 +497,111 @@ func (w *ViperWrapper) Get(key string, bypass bool) (interface{}, bool) {
 	return w.Instance.Get(key), exist
 }
 
-// Set method - set value by given key and write it back to file
+// Unmarshal method - decode the value of the key into the out object.
+// An empty key decodes the whole config; `default` and `validate` tags of the struct are applied.
+// The missing key returns KeyNotExistErr the same as Get.
+func (w *ViperWrapper) Unmarshal(key string, out interface{}) error {
+	w.wg.Wait()
+
+	var input interface{}
+	if key == "" {
+		w.lock.Lock()
+		input = w.Instance.AllSettings()
+		w.lock.Unlock()
+	} else {
+		val, exist := w.Get(key, true)
+		if !exist {
+			return NewKeyNotExistErr(key, w.ConfigName, nil)
+		}
+		input = val
+	}
+
+	return decodeValue(w.ConfigName, key, input, out)
+}
+
+// Set method - set value by given key and write it back to file.
+// The value may be a secret reference; just the reference is written, never the resolved secret.
 func (w *ViperWrapper) Set(key string, value interface{}, bypass bool) error {
 	if !bypass {
 		w.wg.Wait()
 	}
 
+	return w.update(func(tx *Tx) error {
+		return tx.Set(key, value)
+	})
+}
+
+// IsSecretKey - check whether the value of the key is resolved from a secret reference
+func (w *ViperWrapper) IsSecretKey(key string) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	key = strings.ToLower(key)
+	if _, ok := w.secretKeys[key]; ok {
+		return true
+	}
+
+	// the key may point to a map or a list item that holds a secret
+	for k := range w.secretKeys {
+		if strings.HasPrefix(k, key+".") || strings.HasPrefix(key, k+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetKeySource - returns the layer and the file that the effective value of the key came from
+func (w *ViperWrapper) GetKeySource(key string) (KeySource, bool) {
+	w.wg.Wait()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	key = strings.ToLower(key)
+	if v, ok := w.keySources[key]; ok {
+		return v, true
+	}
+
+	// the key may point to a nested map, so report the source with the highest priority among its leaves,
+	// the first leaf in order wins between the same layers
+	var result KeySource
+	resultKey := ""
+	found := false
+	for k, v := range w.keySources {
+		if !strings.HasPrefix(k, key+".") {
+			continue
+		}
+		if !found || w.layerPriority(v) > w.layerPriority(result) ||
+			(w.layerPriority(v) == w.layerPriority(result) && k < resultKey) {
+			result, resultKey, found = v, k, true
+		}
+	}
+	return result, found
+}
+
+// layerPriority - returns the priority of the source by the order of the layers, the env variables are the highest
+func (w *ViperWrapper) layerPriority(source KeySource) int {
+	if source.Layer == EnvLayer {
+		return len(w.ConfigLayers)
+	}
+	for i, layer := range w.ConfigLayers {
+		if layer.Name == source.Layer {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetKeySources - returns the source of all leaf keys of the config
+func (w *ViperWrapper) GetKeySources() map[string]KeySource {
+	w.wg.Wait()
+
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
-	w.Instance.Set(key, value)
-	return w.Instance.SafeWriteConfig()
+	result := make(map[string]KeySource, len(w.keySources))
+	for k, v := range w.keySources {
+		result[k] = v
+	}
+	return result
 }
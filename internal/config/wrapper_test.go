@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -54,3 +56,126 @@ func TestGetExistValue(t *testing.T) {
 		t.Errorf("Get the key for the `type` --> Expected: %v, got %v", expectedVal, actualVal)
 	}
 }
+
+func createLayeredWrapper(t *testing.T) (*ViperWrapper, string) {
+	root := t.TempDir()
+	files := map[string]string{
+		"shared/db.json": `{"server1": {"host": "127.0.0.1", "port": 3306, "options": {"charset": "utf8"}}, "connections": ["server1"]}`,
+		"test/db.json":   `{"server1": {"host": "10.0.0.1", "options": {"loc": "Local"}}}`,
+		"local/db.json":  `{"server1": {"port": 3307}}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		_ = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		_ = os.WriteFile(path, []byte(content), 0644)
+	}
+
+	return &ViperWrapper{
+		ConfigLayers: []ConfigLayer{
+			{Name: SharedLayer, Path: filepath.Join(root, "shared")},
+			{Name: ModeLayer, Path: filepath.Join(root, "test")},
+			{Name: LocalLayer, Path: filepath.Join(root, "local")},
+		},
+		ConfigName: "db",
+	}, root
+}
+
+func TestWrapperLayeredLoad(t *testing.T) {
+	w, root := createLayeredWrapper(t)
+	err := w.Load()
+	if err != nil {
+		t.Errorf("Loading layered wrapper --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	expectedValues := []struct {
+		key         string
+		expectedVal interface{}
+		layer       string
+		file        string
+	}{
+		{key: "server1.host", expectedVal: "10.0.0.1", layer: ModeLayer, file: filepath.Join(root, "test", "db.json")},
+		{key: "server1.port", expectedVal: float64(3307), layer: LocalLayer, file: filepath.Join(root, "local", "db.json")},
+		{key: "server1.options.charset", expectedVal: "utf8", layer: SharedLayer, file: filepath.Join(root, "shared", "db.json")},
+		{key: "server1.options.loc", expectedVal: "Local", layer: ModeLayer, file: filepath.Join(root, "test", "db.json")},
+	}
+
+	for _, item := range expectedValues {
+		val, exist := w.Get(item.key, false)
+		if !exist || !reflect.DeepEqual(item.expectedVal, val) {
+			t.Errorf("Get the key `%v` --> Expected: %v, but got %v", item.key, item.expectedVal, val)
+		}
+
+		source, exist := w.GetKeySource(item.key)
+		expectedSource := KeySource{Layer: item.layer, File: item.file}
+		if !exist || !reflect.DeepEqual(expectedSource, source) {
+			t.Errorf("Source of the key `%v` --> Expected: %v, but got %v", item.key, expectedSource, source)
+		}
+	}
+}
+
+func TestWrapperKeySourceOfMap(t *testing.T) {
+	w, root := createLayeredWrapper(t)
+	err := w.Load()
+	if err != nil {
+		t.Fatalf("Loading layered wrapper --> Expected: %v, but got %v", nil, err)
+	}
+
+	// the map gets the source of its leaf with the highest priority
+	expectedValues := map[string]KeySource{
+		"server1":         {Layer: LocalLayer, File: filepath.Join(root, "local", "db.json")},
+		"server1.options": {Layer: ModeLayer, File: filepath.Join(root, "test", "db.json")},
+	}
+	for key, expected := range expectedValues {
+		for i := 0; i < 10; i++ {
+			source, exist := w.GetKeySource(key)
+			if !exist || !reflect.DeepEqual(expected, source) {
+				t.Errorf("Source of the map `%v` --> Expected: %v, but got %v", key, expected, source)
+				break
+			}
+		}
+	}
+}
+
+func TestWrapperLayeredLoadWithoutFile(t *testing.T) {
+	w := &ViperWrapper{
+		ConfigLayers: []ConfigLayer{{Name: ModeLayer, Path: t.TempDir()}},
+		ConfigName:   "db",
+	}
+
+	err := w.Load()
+	if _, ok := err.(*CategoryNotExistErr); !ok {
+		t.Errorf("Loading layered wrapper without files --> Expected: %T, but got %v", &CategoryNotExistErr{}, err)
+	}
+}
+
+func TestWrapperLayeredReload(t *testing.T) {
+	w, root := createLayeredWrapper(t)
+	err := w.Load()
+	if err != nil {
+		t.Errorf("Loading layered wrapper --> Expected: %v, but got %v", nil, err)
+		return
+	}
+
+	changed := make(chan bool, 10)
+	w.RegisterChangeCallback(func() interface{} {
+		changed <- true
+		return nil
+	})
+	defer w.fileWatcher.Close()
+
+	_ = os.WriteFile(filepath.Join(root, "local", "db.json"), []byte(`{"server1": {"port": 3308}}`), 0644)
+
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Changing the local layer --> Expected the callback to be called, but it is not")
+		return
+	}
+
+	expectedVal := float64(3308)
+	val, _ := w.Get("server1.port", false)
+	if !reflect.DeepEqual(expectedVal, val) {
+		t.Errorf("Get the key `server1.port` after reload --> Expected: %v, but got %v", expectedVal, val)
+	}
+}
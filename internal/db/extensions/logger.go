@@ -64,7 +64,7 @@ func (l DbLogger) Info(ctx context.Context, msg string, data ...interface{}) {
 		l.loggerInstance.Log(types.NewLogObject(
 			types.INFO, "db", DbLogType,
 			time.Now().UTC(), newMsg, nil,
-		))
+		).WithContext(ctx))
 	}
 }
 
@@ -75,7 +75,7 @@ func (l DbLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
 		l.loggerInstance.Log(types.NewLogObject(
 			types.WARNING, "db", DbLogType,
 			time.Now().UTC(), newMsg, nil,
-		))
+		).WithContext(ctx))
 	}
 }
 
@@ -86,11 +86,11 @@ func (l DbLogger) Error(ctx context.Context, msg string, data ...interface{}) {
 		l.loggerInstance.Log(types.NewLogObject(
 			types.ERROR, "db", DbLogType,
 			time.Now().UTC(), newMsg, nil,
-		))
+		).WithContext(ctx))
 	}
 }
 
-// Trace - print sql message
+// Trace - print sql message, the fields of the context (e.g. the request id) are attached to it
 func (l DbLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
 	if l.LogLevel <= logger.Silent {
 		return
@@ -109,13 +109,13 @@ func (l DbLogger) Trace(ctx context.Context, begin time.Time, fc func() (string,
 				l.loggerInstance.Log(types.NewLogObject(
 					types.ERROR, "db", DbTraceLogType,
 					time.Now().UTC(), msg, []interface{}{err, sql},
-				))
+				).WithContext(ctx))
 			} else {
 				msg := fmt.Sprintf(msgLiteral, utils.FileWithLineNum(), err, float64(elapsed.Nanoseconds())/1e6, rows, sql)
 				l.loggerInstance.Log(types.NewLogObject(
 					types.ERROR, "db", DbTraceLogType,
 					time.Now().UTC(), msg, []interface{}{err, sql, rows},
-				))
+				).WithContext(ctx))
 			}
 		case elapsed > l.SlowThreshold && l.SlowThreshold != 0 && l.LogLevel >= logger.Warn:
 			sql, rows := fc()
@@ -127,13 +127,13 @@ func (l DbLogger) Trace(ctx context.Context, begin time.Time, fc func() (string,
 				l.loggerInstance.Log(types.NewLogObject(
 					types.WARNING, "db", DbTraceLogType,
 					time.Now().UTC(), msg, []interface{}{slowLog, sql},
-				))
+				).WithContext(ctx))
 			} else {
 				msg := fmt.Sprintf(msgLiteral, utils.FileWithLineNum(), slowLog, float64(elapsed.Nanoseconds())/1e6, rows, sql)
 				l.loggerInstance.Log(types.NewLogObject(
 					types.WARNING, "db", DbTraceLogType,
 					time.Now().UTC(), msg, []interface{}{slowLog, sql, rows},
-				))
+				).WithContext(ctx))
 			}
 		case l.LogLevel == logger.Info:
 			sql, rows := fc()
@@ -144,13 +144,13 @@ func (l DbLogger) Trace(ctx context.Context, begin time.Time, fc func() (string,
 				l.loggerInstance.Log(types.NewLogObject(
 					types.INFO, "db", DbTraceLogType,
 					time.Now().UTC(), msg, []interface{}{sql},
-				))
+				).WithContext(ctx))
 			} else {
 				msg := fmt.Sprintf(msgLiteral, utils.FileWithLineNum(), float64(elapsed.Nanoseconds())/1e6, rows, sql)
 				l.loggerInstance.Log(types.NewLogObject(
 					types.INFO, "db", DbTraceLogType,
 					time.Now().UTC(), msg, []interface{}{sql, rows},
-				))
+				).WithContext(ctx))
 			}
 		}
 	}
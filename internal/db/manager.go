@@ -18,12 +18,14 @@ import (
 // manager object
 type manager struct {
 	name                string
+	configSource        config.Provider
 	lock                sync.Mutex
 	sqliteDbInstances   map[string]*SqlWrapper[Sqlite]
 	mysqlDbInstances    map[string]*SqlWrapper[Mysql]
 	postgresDbInstances map[string]*SqlWrapper[Postgresql]
 	mongoDbInstances    map[string]*MongoWrapper
 	supportedDBs        []string
+	logger              types.Logger
 
 	isManagerInitialized bool
 }
@@ -48,80 +50,158 @@ func (m *manager) init() {
 	m.supportedDBs = []string{"sqlite", "mysql", "postgresql", "mongodb"}
 
 	// read configs
-	connectionsObj, err := config.GetManager().Get(m.name, "connections")
+	connections, err := config.DecodeFrom[[]string](m.configSource, m.name, "connections")
 	if err != nil {
 		return
 	}
 
+	// the connections of the previous config are closed before they are created again
+	m.closeConnections()
+
 	m.sqliteDbInstances = make(map[string]*SqlWrapper[Sqlite])
 	m.mysqlDbInstances = make(map[string]*SqlWrapper[Mysql])
 	m.postgresDbInstances = make(map[string]*SqlWrapper[Postgresql])
 	m.mongoDbInstances = make(map[string]*MongoWrapper)
 
-	for _, item := range connectionsObj.([]interface{}) {
-		dbInstanceName := item.(string)
-
-		dbTypeKey := fmt.Sprintf("%s.%s", dbInstanceName, "type")
-		dbTypeInf, err := config.GetManager().Get(m.name, dbTypeKey)
-		if err != nil {
-			continue
+	for _, dbInstanceName := range connections {
+		if err := m.initConnection(dbInstanceName); err != nil {
+			log.Printf("Cannot create the db connection `%v`: %v", dbInstanceName, err)
 		}
+	}
 
-		//  create a new instance based on type
-		dbType := strings.ToLower(dbTypeInf.(string))
-		if utils.ArrayContains(&m.supportedDBs, dbType) {
-			switch dbType {
-			case "sqlite":
-				obj, err := NewSqlWrapper[Sqlite](fmt.Sprintf("db/%s", dbInstanceName), dbType)
-				if err != nil {
-					// TODO: log error here
-					continue
-				}
+	m.isManagerInitialized = true
+}
 
-				m.sqliteDbInstances[dbInstanceName] = reflect.ValueOf(obj).Interface().(*SqlWrapper[Sqlite])
-				break
-			case "mysql":
-				obj, err := NewSqlWrapper[Mysql](fmt.Sprintf("db/%s", dbInstanceName), dbType)
-				if err != nil {
-					// TODO: log error here
-					continue
-				}
+// initConnection - create a new instance of the connection based on its type
+func (m *manager) initConnection(dbInstanceName string) error {
+	dbTypeKey := fmt.Sprintf("%s.%s", dbInstanceName, "type")
+	dbTypeInf, err := m.configSource.Get(m.name, dbTypeKey)
+	if err != nil {
+		return err
+	}
 
-				m.mysqlDbInstances[dbInstanceName] = reflect.ValueOf(obj).Interface().(*SqlWrapper[Mysql])
-				break
-			case "postgresql":
-				obj, err := NewSqlWrapper[Postgresql](fmt.Sprintf("db/%s", dbInstanceName), dbType)
-				if err != nil {
-					// TODO: log error here
-					continue
-				}
+	//  create a new instance based on type
+	dbTypeStr, ok := dbTypeInf.(string)
+	if !ok {
+		return NewNotSupportedDbTypeErr(fmt.Sprintf("%v", dbTypeInf))
+	}
+	dbType := strings.ToLower(dbTypeStr)
+	if utils.ArrayContains(&m.supportedDBs, dbType) {
+		switch dbType {
+		case "sqlite":
+			obj, err := newSqlWrapper[Sqlite](m.configSource, fmt.Sprintf("db/%s", dbInstanceName), dbType)
+			if err != nil {
+				return err
+			}
 
-				m.postgresDbInstances[dbInstanceName] = reflect.ValueOf(obj).Interface().(*SqlWrapper[Postgresql])
-				break
-			case "mongodb":
-				obj, err := NewMongoWrapper(fmt.Sprintf("db/%s", dbInstanceName))
-				if err != nil {
-					// TODO: log error here
-					continue
-				}
-				m.mongoDbInstances[dbInstanceName] = obj
+			if m.logger != nil {
+				obj.RegisterLogger(m.logger)
+			}
+			m.sqliteDbInstances[dbInstanceName] = reflect.ValueOf(obj).Interface().(*SqlWrapper[Sqlite])
+			break
+		case "mysql":
+			obj, err := newSqlWrapper[Mysql](m.configSource, fmt.Sprintf("db/%s", dbInstanceName), dbType)
+			if err != nil {
+				return err
+			}
+
+			if m.logger != nil {
+				obj.RegisterLogger(m.logger)
+			}
+			m.mysqlDbInstances[dbInstanceName] = reflect.ValueOf(obj).Interface().(*SqlWrapper[Mysql])
+			break
+		case "postgresql":
+			obj, err := newSqlWrapper[Postgresql](m.configSource, fmt.Sprintf("db/%s", dbInstanceName), dbType)
+			if err != nil {
+				return err
 			}
+
+			if m.logger != nil {
+				obj.RegisterLogger(m.logger)
+			}
+			m.postgresDbInstances[dbInstanceName] = reflect.ValueOf(obj).Interface().(*SqlWrapper[Postgresql])
+			break
+		case "mongodb":
+			obj, err := newMongoWrapper(m.configSource, fmt.Sprintf("db/%s", dbInstanceName))
+			if err != nil {
+				return err
+			}
+			m.mongoDbInstances[dbInstanceName] = obj
 		}
+		return nil
 	}
+	return NewNotSupportedDbTypeErr(dbType)
+}
 
-	m.isManagerInitialized = true
+// removeConnection - close and remove the instance of the connection whatever its type is
+func (m *manager) removeConnection(dbInstanceName string) {
+	if obj, ok := m.sqliteDbInstances[dbInstanceName]; ok {
+		_ = obj.Close()
+	}
+	if obj, ok := m.mysqlDbInstances[dbInstanceName]; ok {
+		_ = obj.Close()
+	}
+	if obj, ok := m.postgresDbInstances[dbInstanceName]; ok {
+		_ = obj.Close()
+	}
+	if obj, ok := m.mongoDbInstances[dbInstanceName]; ok {
+		_ = obj.Close()
+	}
+
+	delete(m.sqliteDbInstances, dbInstanceName)
+	delete(m.mysqlDbInstances, dbInstanceName)
+	delete(m.postgresDbInstances, dbInstanceName)
+	delete(m.mongoDbInstances, dbInstanceName)
+}
+
+// closeConnections - close the instances of all connections
+func (m *manager) closeConnections() {
+	for _, obj := range m.sqliteDbInstances {
+		_ = obj.Close()
+	}
+	for _, obj := range m.mysqlDbInstances {
+		_ = obj.Close()
+	}
+	for _, obj := range m.postgresDbInstances {
+		_ = obj.Close()
+	}
+	for _, obj := range m.mongoDbInstances {
+		_ = obj.Close()
+	}
 }
 
 // restartOnChangeConfig - subscribe a function for when the config is changed
 func (m *manager) restartOnChangeConfig() {
 	// Config config server to reload
-	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	wrapper, err := m.configSource.GetConfigWrapper(m.name)
 	if err == nil {
-		wrapper.RegisterChangeCallback(func() interface{} {
-			if m.isManagerInitialized {
+		wrapper.Subscribe("", func(event config.ChangeEvent) {
+			if !m.isManagerInitialized {
+				return
+			}
+
+			// the list of connections is changed, so all of them must be created again
+			if event.Has("connections") {
 				m.init()
+				return
+			}
+
+			connections, err := config.DecodeFrom[[]string](m.configSource, m.name, "connections")
+			if err != nil {
+				return
+			}
+
+			// just the connections that their config is changed are created again
+			m.lock.Lock()
+			defer m.lock.Unlock()
+			for _, dbInstanceName := range event.Roots() {
+				if utils.ArrayContains(&connections, dbInstanceName) {
+					m.removeConnection(dbInstanceName)
+					if err := m.initConnection(dbInstanceName); err != nil {
+						log.Printf("Cannot create the db connection `%v`: %v", dbInstanceName, err)
+					}
+				}
 			}
-			return nil
 		})
 	} else {
 		// TODO: make some logs
@@ -130,13 +210,25 @@ func (m *manager) restartOnChangeConfig() {
 
 // MARK: Public Functions
 
+// NewManager - create an independent Db Manager that reads its config from the source
+func NewManager(source config.Provider) *manager {
+	return NewManagerWithLogger(source, nil)
+}
+
+// NewManagerWithLogger - create an independent Db Manager that reads its config from the source and registers
+// the logger on its sql connections, the connections that are created on reload get it too
+func NewManagerWithLogger(source config.Provider, l types.Logger) *manager {
+	m := &manager{configSource: source, logger: l}
+	m.init()
+	m.restartOnChangeConfig()
+	return m
+}
+
 // GetManager - This function returns singleton instance of Db Manager
 func GetManager() *manager {
 	// once used for prevent race condition and manage critical section.
 	once.Do(func() {
-		managerInstance = &manager{}
-		managerInstance.init()
-		managerInstance.restartOnChangeConfig()
+		managerInstance = NewManager(config.GetManager())
 	})
 	return managerInstance
 }
@@ -193,7 +285,9 @@ func (m *manager) AttachMigrationFunc(instanceName string, f func(migrator gorm.
 	return NewNotExistServiceNameErr(instanceName)
 }
 
+// RegisterLogger - register the logger on the sql connections, also the ones that are created later
 func (m *manager) RegisterLogger(l types.Logger) {
+	m.logger = l
 	for _, item := range m.sqliteDbInstances {
 		item.RegisterLogger(l)
 	}
@@ -8,9 +8,7 @@ import (
 )
 
 func TestManager_Init(t *testing.T) {
-	makeReadyConfigManager()
-
-	m := manager{}
+	m := manager{configSource: makeReadyConfigManager(t)}
 	m.init()
 
 	if m.name != "db" {
@@ -19,9 +17,7 @@ func TestManager_Init(t *testing.T) {
 }
 
 func TestManager_CheckInitialization(t *testing.T) {
-	makeReadyConfigManager()
-
-	m := manager{}
+	m := manager{configSource: makeReadyConfigManager(t)}
 	m.init()
 
 	if len(m.sqliteDbInstances) != 1 {
@@ -43,9 +39,7 @@ func TestManager_CheckInitialization(t *testing.T) {
 }
 
 func TestManager_CheckInitializationMongo(t *testing.T) {
-	makeReadyConfigManager()
-
-	m := manager{}
+	m := manager{configSource: makeReadyConfigManager(t)}
 	m.init()
 
 	if len(m.mongoDbInstances) != 1 {
@@ -67,9 +61,7 @@ func TestManager_CheckInitializationMongo(t *testing.T) {
 }
 
 func TestManager_TestGetDbFunc(t *testing.T) {
-	makeReadyConfigManager()
-
-	m := manager{}
+	m := manager{configSource: makeReadyConfigManager(t)}
 	m.init()
 
 	if len(m.sqliteDbInstances) != 1 {
@@ -92,10 +84,89 @@ func TestManager_TestGetDbFunc(t *testing.T) {
 	}
 }
 
-func makeReadyConfigManager() {
-	path := "../.."
-	initialMode := "test"
-	prefix := "ZHYCAN"
+func TestManager_InMemoryConfig(t *testing.T) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"db": {
+			"connections": []interface{}{"server1"},
+			"server1": map[string]interface{}{
+				"type":    "sqlite",
+				"db":      "file.db",
+				"options": map[string]interface{}{"mode": "memory", "cache": "shared"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating in-memory config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	m := NewManager(source)
+	if len(m.sqliteDbInstances) != 1 {
+		t.Errorf("Expected manager have %v instance of sqlite, but got %v", 1, len(m.sqliteDbInstances))
+		return
+	}
+
+	_, err = m.GetDb("server1")
+	if err != nil {
+		t.Errorf("Get Db Instance --> Expected error: %v, but got %v", nil, err)
+	}
+}
+
+func makeReadyConfigManager(t *testing.T) config.Provider {
+	source, err := config.New(config.Options{BasePath: "../..", Mode: "test", EnvPrefix: "ZHYCAN"})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+	return source
+}
+
+func TestManager_RemoveConnectionCloses(t *testing.T) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"db": {
+			"connections": []interface{}{"server1"},
+			"server1": map[string]interface{}{
+				"type":    "sqlite",
+				"db":      "file.db",
+				"options": map[string]interface{}{"mode": "memory", "cache": "shared"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating in-memory config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	m := NewManager(source)
+	gormDb, err := m.GetDb("server1")
+	if err != nil {
+		t.Fatalf("Get Db Instance --> Expected error: %v, but got %v", nil, err)
+	}
+	sqlDb, err := gormDb.DB()
+	if err != nil {
+		t.Fatalf("Get Sql Db Instance --> Expected error: %v, but got %v", nil, err)
+	}
+
+	m.removeConnection("server1")
+	if err := sqlDb.Ping(); err == nil {
+		t.Errorf("Ping the removed connection --> Expected an error, but got %v", err)
+	}
+}
+
+func TestManager_InvalidDbType(t *testing.T) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"db": {
+			"connections": []interface{}{"server1"},
+			"server1":     map[string]interface{}{"type": 5},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating in-memory config manager --> Expected: %v, but got %v", nil, err)
+	}
 
-	_ = config.CreateManager(path, initialMode, prefix)
+	m := NewManager(source)
+	err = m.initConnection("server1")
+	if err == nil {
+		t.Errorf("Creating the connection with the invalid type --> Expected an error, but got %v", err)
+	}
+	if _, err := m.GetDb("server1"); err == nil {
+		t.Errorf("Get Db Instance --> Expected an error, but got %v", err)
+	}
 }
@@ -2,7 +2,6 @@ package db
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/db/extensions"
@@ -21,86 +20,19 @@ type MongoWrapper struct {
 	databaseInstance *mongo.Client
 }
 
-func (m *MongoWrapper) init(name string) error {
+func (m *MongoWrapper) init(source config.Provider, name string) error {
 	m.name = name
 
 	// reading config
 	nameParts := strings.Split(m.name, "/")
 
-	var tempConfig *Mongo
-	tempConfigObj, err := config.GetManager().Get(nameParts[0], nameParts[1])
-	if err == nil {
-		// first marshal
-		configData, err := json.Marshal(tempConfigObj)
-		if err == nil {
-			_ = json.Unmarshal(configData, &tempConfig)
-		}
-	}
-	m.config = tempConfig
-
-	//dbNameKey := fmt.Sprintf("%s.%s", nameParts[1], "db")
-	//dbNameStr, err := config.GetManager().Get(nameParts[0], dbNameKey)
-	//if err != nil {
-	//	return err
-	//}
-	//
-	//hostKey := fmt.Sprintf("%s.%s", nameParts[1], "host")
-	//hostStr, err := config.GetManager().Get(nameParts[0], hostKey)
-	//if err != nil {
-	//	return err
-	//}
-	//
-	//portKey := fmt.Sprintf("%s.%s", nameParts[1], "port")
-	//portStr, err := config.GetManager().Get(nameParts[0], portKey)
-	//if err != nil {
-	//	return err
-	//}
-	//
-	//usernameKey := fmt.Sprintf("%s.%s", nameParts[1], "username")
-	//usernameStr, err := config.GetManager().Get(nameParts[0], usernameKey)
-	//if err != nil {
-	//	return err
-	//}
-	//
-	//passwordKey := fmt.Sprintf("%s.%s", nameParts[1], "password")
-	//passwordStr, err := config.GetManager().Get(nameParts[0], passwordKey)
-	//if err != nil {
-	//	return err
-	//}
-
-	optionsKey := fmt.Sprintf("%s.%s", nameParts[1], "options")
-	optionsObj, err := config.GetManager().Get(nameParts[0], optionsKey)
+	var tempConfig Mongo
+	err := source.Unmarshal(nameParts[0], nameParts[1], &tempConfig)
 	if err != nil {
 		return err
 	}
+	m.config = &tempConfig
 
-	optionsMap := make(map[string]string, len(optionsObj.(map[string]interface{})))
-	for key, item := range optionsObj.(map[string]interface{}) {
-		optionsMap[key] = item.(string)
-	}
-	m.config.Options = optionsMap
-
-	var internalLogger *MongoLoggerConfig
-
-	internalLoggerKey := fmt.Sprintf("%s.%s", nameParts[1], "logger")
-	internalLoggerObj, err := config.GetManager().Get(nameParts[0], internalLoggerKey)
-	if err == nil {
-		// first marshal
-		configData, err := json.Marshal(internalLoggerObj)
-		if err == nil {
-			_ = json.Unmarshal(configData, &internalLogger)
-		}
-	}
-	m.config.LoggerConfig = internalLogger
-
-	//m.config = &Mongo{
-	//	DatabaseName: dbNameStr.(string),
-	//	Username:     usernameStr.(string),
-	//	Password:     passwordStr.(string),
-	//	Host:         hostStr.(string),
-	//	Port:         portStr.(string),
-	//	Options:      optionsMap,
-	//}
 	return nil
 }
 
@@ -160,10 +92,26 @@ func (m *MongoWrapper) GetDb() (*mongo.Database, error) {
 	return actualDb, nil
 }
 
+// Close - disconnect the client of the database, the next GetDb connects it again
+func (m *MongoWrapper) Close() error {
+	if m.databaseInstance == nil {
+		return nil
+	}
+
+	client := m.databaseInstance
+	m.databaseInstance = nil
+	return client.Disconnect(context.TODO())
+}
+
 // NewMongoWrapper - create a new instance of MongoWrapper and returns it
 func NewMongoWrapper(name string) (*MongoWrapper, error) {
+	return newMongoWrapper(config.GetManager(), name)
+}
+
+// newMongoWrapper - create a new instance of MongoWrapper that reads its config from the source
+func newMongoWrapper(source config.Provider, name string) (*MongoWrapper, error) {
 	wrapper := &MongoWrapper{}
-	err := wrapper.init(name)
+	err := wrapper.init(source, name)
 	if err != nil {
 		return nil, NewCreateMongoWrapperErr(err)
 	}
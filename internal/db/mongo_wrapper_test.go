@@ -6,7 +6,7 @@ import (
 )
 
 func TestMongoWrapper_Initialize(t *testing.T) {
-	makeReadyConfigManager()
+	source := makeReadyConfigManager(t)
 
 	wrapper := &MongoWrapper{name: "db/server4", config: &Mongo{
 		DatabaseName: "m",
@@ -20,7 +20,7 @@ func TestMongoWrapper_Initialize(t *testing.T) {
 			"connecttimeoutms": "30000",
 		},
 	}}
-	newWrapper, err := NewMongoWrapper("db/server4")
+	newWrapper, err := newMongoWrapper(source, "db/server4")
 
 	if err != nil {
 		t.Errorf("Creating Mongo Wrapper --> Expected: %v, but got %v", nil, err)
@@ -0,0 +1,46 @@
+package db
+
+// Imports needed list
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+)
+
+// Schema - returns the JSON Schema of the `db` config module,
+// every connection is validated based on its `type`
+func Schema() *config.Schema {
+	connectionTypes := []struct {
+		name string
+		cfg  interface{}
+	}{
+		{name: "sqlite", cfg: Sqlite{}},
+		{name: "mysql", cfg: Mysql{}},
+		{name: "postgresql", cfg: Postgresql{}},
+		{name: "mongodb", cfg: Mongo{}},
+	}
+
+	typeSchema := &config.Schema{Type: "string"}
+	connection := &config.Schema{
+		Type:       "object",
+		Required:   []string{"type"},
+		Properties: map[string]*config.Schema{"type": typeSchema},
+	}
+	for _, item := range connectionTypes {
+		typeSchema.Enum = append(typeSchema.Enum, item.name)
+		connection.AllOf = append(connection.AllOf, &config.Schema{
+			If: &config.Schema{
+				Required:   []string{"type"},
+				Properties: map[string]*config.Schema{"type": {Const: item.name}},
+			},
+			Then: config.SchemaOf(item.cfg),
+		})
+	}
+
+	return config.ModuleSchema("db", &config.Schema{
+		Type:     "object",
+		Required: []string{"connections"},
+		Properties: map[string]*config.Schema{
+			"connections": {Type: "array", Items: &config.Schema{Type: "string"}},
+		},
+		AdditionalProperties: connection,
+	})
+}
@@ -26,246 +26,18 @@ type SqlWrapper[T SqlConfigurable] struct {
 }
 
 // init - SqlWrapper Constructor - It initializes the wrapper
-func (s *SqlWrapper[T]) init(name string) error {
+func (s *SqlWrapper[T]) init(source config.Provider, name string) error {
 	s.name = name
 
 	// reading config
 	nameParts := strings.Split(s.name, "/")
 
-	if reflect.ValueOf(s.config).Type() == reflect.TypeOf(Sqlite{}) {
-		filenameKey := fmt.Sprintf("%s.%s", nameParts[1], "db")
-		filenameStr, err := config.GetManager().Get(nameParts[0], filenameKey)
-		if err != nil {
-			return err
-		}
-
-		optionsKey := fmt.Sprintf("%s.%s", nameParts[1], "options")
-		optionsObj, err := config.GetManager().Get(nameParts[0], optionsKey)
-		if err != nil {
-			return err
-		}
-
-		optionsMap := make(map[string]string, len(optionsObj.(map[string]interface{})))
-		for key, item := range optionsObj.(map[string]interface{}) {
-			optionsMap[key] = item.(string)
-		}
-
-		var internalConfig *Config
-
-		internalConfigKey := fmt.Sprintf("%s.%s", nameParts[1], "config")
-		internalConfigObj, err := config.GetManager().Get(nameParts[0], internalConfigKey)
-		if err == nil {
-			// first marshal
-			configData, err := json.Marshal(internalConfigObj)
-			if err == nil {
-				_ = json.Unmarshal(configData, &internalConfig)
-			}
-		}
-
-		var internalLogger *LoggerConfig
-
-		internalLoggerKey := fmt.Sprintf("%s.%s", nameParts[1], "logger")
-		internalLoggerObj, err := config.GetManager().Get(nameParts[0], internalLoggerKey)
-		if err == nil {
-			// first marshal
-			configData, err := json.Marshal(internalLoggerObj)
-			if err == nil {
-				_ = json.Unmarshal(configData, &internalLogger)
-			}
-		}
-
-		s.config = reflect.ValueOf(Sqlite{
-			FileName:     filenameStr.(string),
-			Options:      optionsMap,
-			Config:       internalConfig,
-			LoggerConfig: internalLogger,
-		}).Interface().(T)
-	} else if reflect.ValueOf(s.config).Type() == reflect.TypeOf(Mysql{}) {
-		dbNameKey := fmt.Sprintf("%s.%s", nameParts[1], "db")
-		dbNameStr, err := config.GetManager().Get(nameParts[0], dbNameKey)
-		if err != nil {
-			return err
-		}
-
-		hostKey := fmt.Sprintf("%s.%s", nameParts[1], "host")
-		hostStr, err := config.GetManager().Get(nameParts[0], hostKey)
-		if err != nil {
-			return err
-		}
-
-		portKey := fmt.Sprintf("%s.%s", nameParts[1], "port")
-		portStr, err := config.GetManager().Get(nameParts[0], portKey)
-		if err != nil {
-			return err
-		}
-
-		protocolKey := fmt.Sprintf("%s.%s", nameParts[1], "protocol")
-		protocolStr, err := config.GetManager().Get(nameParts[0], protocolKey)
-		if err != nil {
-			return err
-		}
-
-		usernameKey := fmt.Sprintf("%s.%s", nameParts[1], "username")
-		usernameStr, err := config.GetManager().Get(nameParts[0], usernameKey)
-		if err != nil {
-			return err
-		}
-
-		passwordKey := fmt.Sprintf("%s.%s", nameParts[1], "password")
-		passwordStr, err := config.GetManager().Get(nameParts[0], passwordKey)
-		if err != nil {
-			return err
-		}
-
-		optionsKey := fmt.Sprintf("%s.%s", nameParts[1], "options")
-		optionsObj, err := config.GetManager().Get(nameParts[0], optionsKey)
-		if err != nil {
-			return err
-		}
-
-		optionsMap := make(map[string]string, len(optionsObj.(map[string]interface{})))
-		for key, item := range optionsObj.(map[string]interface{}) {
-			optionsMap[key] = item.(string)
-		}
-
-		var internalConfig *Config
-
-		internalConfigKey := fmt.Sprintf("%s.%s", nameParts[1], "config")
-		internalConfigObj, err := config.GetManager().Get(nameParts[0], internalConfigKey)
-		if err == nil {
-			// first marshal
-			configData, err := json.Marshal(internalConfigObj)
-			if err == nil {
-				_ = json.Unmarshal(configData, &internalConfig)
-			}
-		}
-
-		var internalLogger *LoggerConfig
-
-		internalLoggerKey := fmt.Sprintf("%s.%s", nameParts[1], "logger")
-		internalLoggerObj, err := config.GetManager().Get(nameParts[0], internalLoggerKey)
-		if err == nil {
-			// first marshal
-			configData, err := json.Marshal(internalLoggerObj)
-			if err == nil {
-				_ = json.Unmarshal(configData, &internalLogger)
-			}
-		}
-
-		var specificConfig *MysqlSpecificConfig
-
-		specificConfigKey := fmt.Sprintf("%s.%s", nameParts[1], "specific_config")
-		specificConfigObj, err := config.GetManager().Get(nameParts[0], specificConfigKey)
-		if err == nil {
-			// first marshal
-			configData, err := json.Marshal(specificConfigObj)
-			if err == nil {
-				_ = json.Unmarshal(configData, &specificConfig)
-			}
-		}
-
-		s.config = reflect.ValueOf(Mysql{
-			DatabaseName:   dbNameStr.(string),
-			Username:       usernameStr.(string),
-			Password:       passwordStr.(string),
-			Host:           hostStr.(string),
-			Port:           portStr.(string),
-			Protocol:       protocolStr.(string),
-			Options:        optionsMap,
-			Config:         internalConfig,
-			LoggerConfig:   internalLogger,
-			SpecificConfig: specificConfig,
-		}).Interface().(T)
-	} else if reflect.ValueOf(s.config).Type() == reflect.TypeOf(Postgresql{}) {
-		dbNameKey := fmt.Sprintf("%s.%s", nameParts[1], "db")
-		dbNameStr, err := config.GetManager().Get(nameParts[0], dbNameKey)
-		if err != nil {
-			return err
-		}
-
-		hostKey := fmt.Sprintf("%s.%s", nameParts[1], "host")
-		hostStr, err := config.GetManager().Get(nameParts[0], hostKey)
-		if err != nil {
-			return err
-		}
-
-		portKey := fmt.Sprintf("%s.%s", nameParts[1], "port")
-		portStr, err := config.GetManager().Get(nameParts[0], portKey)
-		if err != nil {
-			return err
-		}
-
-		usernameKey := fmt.Sprintf("%s.%s", nameParts[1], "username")
-		usernameStr, err := config.GetManager().Get(nameParts[0], usernameKey)
-		if err != nil {
-			return err
-		}
-
-		passwordKey := fmt.Sprintf("%s.%s", nameParts[1], "password")
-		passwordStr, err := config.GetManager().Get(nameParts[0], passwordKey)
-		if err != nil {
-			return err
-		}
-
-		optionsKey := fmt.Sprintf("%s.%s", nameParts[1], "options")
-		optionsObj, err := config.GetManager().Get(nameParts[0], optionsKey)
-		if err != nil {
-			return err
-		}
-
-		optionsMap := make(map[string]string, len(optionsObj.(map[string]interface{})))
-		for key, item := range optionsObj.(map[string]interface{}) {
-			optionsMap[key] = item.(string)
-		}
-
-		var internalConfig *Config
-
-		internalConfigKey := fmt.Sprintf("%s.%s", nameParts[1], "config")
-		internalConfigObj, err := config.GetManager().Get(nameParts[0], internalConfigKey)
-		if err == nil {
-			// first marshal
-			configData, err := json.Marshal(internalConfigObj)
-			if err == nil {
-				_ = json.Unmarshal(configData, &internalConfig)
-			}
-		}
-
-		var internalLogger *LoggerConfig
-
-		internalLoggerKey := fmt.Sprintf("%s.%s", nameParts[1], "logger")
-		internalLoggerObj, err := config.GetManager().Get(nameParts[0], internalLoggerKey)
-		if err == nil {
-			// first marshal
-			configData, err := json.Marshal(internalLoggerObj)
-			if err == nil {
-				_ = json.Unmarshal(configData, &internalLogger)
-			}
-		}
-
-		var specificConfig *PostgresqlSpecificConfig
-
-		specificConfigKey := fmt.Sprintf("%s.%s", nameParts[1], "specific_config")
-		specificConfigObj, err := config.GetManager().Get(nameParts[0], specificConfigKey)
-		if err == nil {
-			// first marshal
-			configData, err := json.Marshal(specificConfigObj)
-			if err == nil {
-				_ = json.Unmarshal(configData, &specificConfig)
-			}
-		}
-
-		s.config = reflect.ValueOf(Postgresql{
-			DatabaseName:   dbNameStr.(string),
-			Username:       usernameStr.(string),
-			Password:       passwordStr.(string),
-			Host:           hostStr.(string),
-			Port:           portStr.(string),
-			Options:        optionsMap,
-			Config:         internalConfig,
-			LoggerConfig:   internalLogger,
-			SpecificConfig: specificConfig,
-		}).Interface().(T)
+	var cfg T
+	err := source.Unmarshal(nameParts[0], nameParts[1], &cfg)
+	if err != nil {
+		return err
 	}
+	s.config = cfg
 
 	return nil
 }
@@ -452,13 +224,32 @@ func (s *SqlWrapper[T]) AttachMigrationFunc(f func(migrator gorm.Migrator) error
 	return nil
 }
 
+// Close - close the connections of the database, the next GetDb opens it again
+func (s *SqlWrapper[T]) Close() error {
+	if s.databaseInstance == nil {
+		return nil
+	}
+
+	sqlDb, err := s.databaseInstance.DB()
+	if err != nil {
+		return err
+	}
+	s.databaseInstance = nil
+	return sqlDb.Close()
+}
+
 // NewSqlWrapper - create a new instance of SqlWrapper and returns it
 func NewSqlWrapper[T SqlConfigurable](name string, dbType string) (*SqlWrapper[T], error) {
+	return newSqlWrapper[T](config.GetManager(), name, dbType)
+}
+
+// newSqlWrapper - create a new instance of SqlWrapper that reads its config from the source
+func newSqlWrapper[T SqlConfigurable](source config.Provider, name string, dbType string) (*SqlWrapper[T], error) {
 	if strings.ToLower(dbType) == "sqlite" ||
 		strings.ToLower(dbType) == "mysql" ||
 		strings.ToLower(dbType) == "postgresql" {
 		wrapper := &SqlWrapper[T]{}
-		err := wrapper.init(name)
+		err := wrapper.init(source, name)
 		if err != nil {
 			return nil, NewCreateSqlWrapperErr(err)
 		}
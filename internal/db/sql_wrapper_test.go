@@ -22,9 +22,9 @@ func TestSqlWrapper_Initialize(t *testing.T) {
 }
 
 func TestSqlWrapper_SqliteConnection(t *testing.T) {
-	makeReadyConfigManager()
+	source := makeReadyConfigManager(t)
 
-	newWrapper, err := NewSqlWrapper[Sqlite]("db/server1", "sqlite")
+	newWrapper, err := newSqlWrapper[Sqlite](source, "db/server1", "sqlite")
 	if err != nil {
 		t.Errorf("Creating Sql Wrapper --> Expected: %v, but got %v", nil, err)
 		return
@@ -50,9 +50,9 @@ func TestSqlWrapper_SqliteConnection(t *testing.T) {
 }
 
 func TestSqlWrapper_AddLoggerAndTest(t *testing.T) {
-	makeReadyConfigManager()
+	source := makeReadyConfigManager(t)
 
-	newWrapper, err := NewSqlWrapper[Sqlite]("db/server1", "sqlite")
+	newWrapper, err := newSqlWrapper[Sqlite](source, "db/server1", "sqlite")
 	if err != nil {
 		t.Errorf("Creating Sql Wrapper --> Expected: %v, but got %v", nil, err)
 		return
@@ -101,9 +101,9 @@ func TestSqlWrapper_SqliteConnectionConfiguration(t *testing.T) {
 }
 
 func TestSqlWrapper_MigrateTest(t *testing.T) {
-	makeReadyConfigManager()
+	source := makeReadyConfigManager(t)
 
-	newWrapper, err := NewSqlWrapper[Sqlite]("db/server1", "sqlite")
+	newWrapper, err := newSqlWrapper[Sqlite](source, "db/server1", "sqlite")
 	if err != nil {
 		t.Errorf("Creating Sql Wrapper --> Expected: %v, but got %v", nil, err)
 		return
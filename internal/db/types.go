@@ -39,19 +39,19 @@ type PostgresqlSpecificConfig struct {
 }
 
 type Sqlite struct {
-	FileName     string            `json:"db"`
+	FileName     string            `json:"db" validate:"required"`
 	Options      map[string]string `json:"options"`
 	Config       *Config           `json:"config"`
 	LoggerConfig *LoggerConfig     `json:"logger"`
 }
 
 type Mysql struct {
-	DatabaseName   string               `json:"db"`
-	Username       string               `json:"username"`
+	DatabaseName   string               `json:"db" validate:"required"`
+	Username       string               `json:"username" validate:"required"`
 	Password       string               `json:"password"`
-	Host           string               `json:"host"`
-	Port           string               `json:"port"`
-	Protocol       string               `json:"protocol"`
+	Host           string               `json:"host" validate:"required"`
+	Port           string               `json:"port" validate:"required"`
+	Protocol       string               `json:"protocol" default:"tcp"`
 	Options        map[string]string    `json:"options"`
 	Config         *Config              `json:"config"`
 	LoggerConfig   *LoggerConfig        `json:"logger"`
@@ -59,11 +59,11 @@ type Mysql struct {
 }
 
 type Postgresql struct {
-	DatabaseName   string                    `json:"db"`
-	Username       string                    `json:"username"`
+	DatabaseName   string                    `json:"db" validate:"required"`
+	Username       string                    `json:"username" validate:"required"`
 	Password       string                    `json:"password"`
-	Host           string                    `json:"host"`
-	Port           string                    `json:"port"`
+	Host           string                    `json:"host" validate:"required"`
+	Port           string                    `json:"port" validate:"required"`
 	Options        map[string]string         `json:"options"`
 	Config         *Config                   `json:"config"`
 	LoggerConfig   *LoggerConfig             `json:"logger"`
@@ -81,11 +81,11 @@ type MongoLoggerConfig struct {
 }
 
 type Mongo struct {
-	DatabaseName string             `json:"db"`
-	Username     string             `json:"username"`
+	DatabaseName string             `json:"db" validate:"required"`
+	Username     string             `json:"username" validate:"required"`
 	Password     string             `json:"password"`
-	Host         string             `json:"host"`
-	Port         string             `json:"port"`
+	Host         string             `json:"host" validate:"required"`
+	Port         string             `json:"port" validate:"required"`
 	Options      map[string]string  `json:"options"`
 	LoggerConfig *MongoLoggerConfig `json:"logger"`
 }
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+)
+
+// MARK: contextServerStream
+
+// contextServerStream - the server stream with the context that carries the log fields
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context - returns the context that carries the log fields
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// MARK: Private Functions
+
+// metadataValue - returns the first value of the key in the incoming metadata
+func metadataValue(md metadata.MD, key string) string {
+	values := md.Get(strings.ToLower(key))
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// contextFromMetadata - put the request id and the trace of the incoming metadata in the context,
+// the request id is generated if the caller has not sent it and is sent back in the header
+func contextFromMetadata(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx, requestID := types.ContextFromRequest(
+		ctx,
+		metadataValue(md, types.RequestIDHeader),
+		metadataValue(md, types.TraceParentHeader),
+	)
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(types.RequestIDHeader), requestID))
+	return ctx
+}
+
+// contextUnaryInterceptor - the interceptor of the unary methods that puts the log fields in the context
+func contextUnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(contextFromMetadata(ctx), req)
+}
+
+// contextStreamInterceptor - the interceptor of the stream methods that puts the log fields in the context
+func contextStreamInterceptor(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: contextFromMetadata(ss.Context())})
+}
@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+func TestContextUnaryInterceptor(t *testing.T) {
+	md := metadata.Pairs("x-request-id", "req-1", "traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	var got context.Context
+	_, err := contextUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = ctx
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Calling the interceptor --> Expected: %v, but got %v", nil, err)
+	}
+
+	if v := types.ContextValue(got, types.RequestIDKey); v != "req-1" {
+		t.Errorf("Request id of the context --> Expected: %v, but got %v", "req-1", v)
+	}
+	if v := types.ContextValue(got, types.TraceIDKey); v != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Trace id of the context --> Expected: %v, but got %v", "4bf92f3577b34da6a3ce929d0e0e4736", v)
+	}
+}
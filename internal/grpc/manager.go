@@ -1,7 +1,6 @@
 package grpc
 
 import (
-	"encoding/json"
 	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/logger"
 	"github.com/abolfazlbeh/zhycan/internal/logger/types"
@@ -20,10 +19,12 @@ var (
 
 // manager struct
 type manager struct {
-	name      string
-	lock      sync.Mutex
-	servers   map[string]*ServerWrapper
-	isStarted bool
+	name         string
+	configSource config.Provider
+	lock         sync.Mutex
+	servers      map[string]*ServerWrapper
+	isStarted    bool
+	logger       types.Logger
 }
 
 // MARK: Module variables
@@ -42,41 +43,27 @@ func (m *manager) init() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	servers, err := config.GetManager().Get(m.name, "servers")
+	serverArray, err := config.DecodeFrom[[]string](m.configSource, m.name, "servers")
 	if err != nil {
 		return
 	}
 
-	serverArray := make([]string, len(servers.([]interface{})))
-	for i, v := range servers.([]interface{}) {
-		serverArray[i] = v.(string)
-	}
-
 	m.servers = make(map[string]*ServerWrapper)
 
 	for _, item := range serverArray {
-		conf, err := config.GetManager().Get(m.name, item)
+		obj, err := config.DecodeFrom[ServerConfig](m.configSource, m.name, item)
 		if err != nil {
 			continue
 		}
 
-		jsonBody, err2 := json.Marshal(conf)
-		if err2 != nil {
-			continue
-		}
-
-		var obj ServerConfig
-		err = json.Unmarshal(jsonBody, &obj)
+		s, err := newServer(m.name+"."+item, obj, m.logger)
 		if err == nil {
-			s, err := NewServer(m.name+"."+item, obj)
-			if err == nil {
-				m.servers[item] = s
-			}
+			m.servers[item] = s
 		}
 	}
 
 	// Config config server to reload
-	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	wrapper, err := m.configSource.GetConfigWrapper(m.name)
 	if err == nil {
 		wrapper.RegisterChangeCallback(func() interface{} {
 			return nil
@@ -86,19 +73,31 @@ func (m *manager) init() {
 
 // MARK: Public Functions
 
+// NewManager - create an independent gRPC Manager that reads its config from the source
+func NewManager(source config.Provider) *manager {
+	return NewManagerWithLogger(source, nil)
+}
+
+// NewManagerWithLogger - create an independent gRPC Manager that reads its config from the source and its servers
+// log through the logger, the nil logger is the logger of the default manager
+func NewManagerWithLogger(source config.Provider, l types.Logger) *manager {
+	m := &manager{configSource: source, logger: l}
+	m.init()
+	return m
+}
+
 // GetManager - This function returns singleton instance of gRPC Manager
 func GetManager() *manager {
 	// once used for prevent race condition and manage critical section.
 	once.Do(func() {
-		managerInstance = &manager{}
-		managerInstance.init()
+		managerInstance = NewManager(config.GetManager())
 	})
 	return managerInstance
 }
 
 // StartServers - This function starts the gRPC servers
 func (m *manager) StartServers() {
-	l, _ := logger.GetManager().GetLogger()
+	l := logger.LoggerOrDefault(m.logger)
 
 	m.lock.Lock()
 	defer m.lock.Unlock()
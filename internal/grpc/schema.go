@@ -0,0 +1,14 @@
+package grpc
+
+// Imports needed list
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+)
+
+// Schema - returns the JSON Schema of the `protobuf` config module,
+// every other key is the config of a server
+func Schema() *config.Schema {
+	s := config.SchemaOf(Config{})
+	s.AdditionalProperties = config.SchemaOf(ServerConfig{})
+	return config.ModuleSchema("protobuf", s)
+}
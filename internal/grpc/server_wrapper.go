@@ -24,6 +24,7 @@ type ServerWrapper struct {
 	listener    net.Listener
 	initialized bool
 	config      ServerConfig
+	logger      types.Logger
 	//authObj     *auth.Authentication
 	//authEnable bool
 }
@@ -77,6 +78,10 @@ func (s *ServerWrapper) generateConfigs(configs map[string]interface{}) []grpc.S
 		Timeout: 20 * time.Second,
 	}))
 
+	// every call gets the request id and the trace in its context for the logs
+	options = append(options, grpc.ChainUnaryInterceptor(contextUnaryInterceptor))
+	options = append(options, grpc.ChainStreamInterceptor(contextStreamInterceptor))
+
 	return options
 }
 
@@ -84,7 +89,12 @@ func (s *ServerWrapper) generateConfigs(configs map[string]interface{}) []grpc.S
 
 // NewServer - create a new instance of Server and return it
 func NewServer(name string, config ServerConfig) (*ServerWrapper, error) {
-	server := &ServerWrapper{}
+	return newServer(name, config, nil)
+}
+
+// newServer - create a new instance of Server that logs through the logger, the nil logger is the logger of the default manager
+func newServer(name string, config ServerConfig, l types.Logger) (*ServerWrapper, error) {
+	server := &ServerWrapper{logger: l}
 	err := server.init(name, config)
 	if err != nil {
 		return nil, NewCreateServerErr(err)
@@ -95,7 +105,7 @@ func NewServer(name string, config ServerConfig) (*ServerWrapper, error) {
 
 // Start - start the server with option of async capability
 func (s *ServerWrapper) Start(ch *chan error) error {
-	l, _ := logger.GetManager().GetLogger()
+	l := logger.LoggerOrDefault(s.logger)
 	if l != nil {
 		l.Log(types.NewLogObject(types.INFO, "protobuf.Server.Start", ServerMaintenanceType, time.Now(), "Starting the gRPC server ...", s.listener))
 	}
@@ -1,9 +1,15 @@
 package grpc
 
+// Config - the structure of the `protobuf` config module
+type Config struct {
+	Proto   int      `json:"proto"`
+	Servers []string `json:"servers" validate:"required"`
+}
+
 type ServerConfig struct {
 	Host       string                 `json:"host"`
-	Port       int                    `json:"port"`
-	Protocol   string                 `json:"protocol"`
+	Port       int                    `json:"port" validate:"min=0,max=65535"`
+	Protocol   string                 `json:"protocol" default:"tcp" validate:"oneof=tcp tcp4 tcp6 unix"`
 	Async      bool                   `json:"async"`
 	Reflection bool                   `json:"reflection"`
 	Configs    map[string]interface{} `json:"configs"`
@@ -0,0 +1,113 @@
+package http
+
+// Imports needed list
+import (
+	"crypto/subtle"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/http/types"
+	"github.com/abolfazlbeh/zhycan/internal/logger"
+	logTypes "github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"github.com/gin-gonic/gin"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// MARK: Private Types
+
+// levelManager - the logger manager that its levels are served by the admin routes
+type levelManager interface {
+	Levels() (logTypes.Levels, *logger.Error)
+	SetLevels(levels logTypes.Levels) *logger.Error
+}
+
+// MARK: Private Functions
+
+// adminAuthMiddleware - check the bearer token of the admin routes, without the token just the loopback clients
+// are served, so the admin routes are never open to the network
+func adminAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			// the remote address of the connection, the forwarded headers can be forged
+			ip := net.ParseIP(c.RemoteIP())
+			if ip == nil || !ip.IsLoopback() {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "the admin routes without token are just served to localhost"})
+				return
+			}
+			c.Next()
+			return
+		}
+
+		provided := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// configIntrospectionHandler - returns the effective config of all modules of the source with the source of the keys,
+// the passwords, tokens and secrets are redacted
+func configIntrospectionHandler(source config.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, source.Introspect())
+	}
+}
+
+// loggerLevelsHandler - returns the current levels of the logger outputs and modules
+func loggerLevelsHandler(getManager func() levelManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		m := getManager()
+		levels, err := m.Levels()
+		if err != nil {
+			c.JSON(http.StatusNotImplemented, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, levels)
+	}
+}
+
+// updateLoggerLevelsHandler - change the levels of the logger outputs and modules, e.g. `{"modules": {"db": "debug"}}`,
+// the empty level or `default` removes the level of the module
+func updateLoggerLevelsHandler(getManager func() levelManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		m := getManager()
+		var levels logTypes.Levels
+		if err := c.ShouldBindJSON(&levels); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := m.SetLevels(levels); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		current, err := m.Levels()
+		if err != nil {
+			c.JSON(http.StatusNotImplemented, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, current)
+	}
+}
+
+// MARK: Private Methods
+
+// levelManager - returns the manager of the logger that the server logs through
+func (s *GinServer) levelManager() levelManager {
+	return logger.ManagerOf(s.logger)
+}
+
+// attachAdminRoutes - register the admin routes on the base router of the server
+func (s *GinServer) attachAdminRoutes(adminConfig types.AdminConfig) {
+	if !adminConfig.Enabled {
+		return
+	}
+
+	auth := adminAuthMiddleware(adminConfig.Token)
+	s.baseRouter.GET(adminConfig.ConfigPath, auth, configIntrospectionHandler(s.configSource))
+	s.baseRouter.GET(adminConfig.LoggerPath, auth, loggerLevelsHandler(s.levelManager))
+	s.baseRouter.PUT(adminConfig.LoggerPath, auth, updateLoggerLevelsHandler(s.levelManager))
+}
@@ -29,6 +29,8 @@ var (
 // GinServer struct
 type GinServer struct {
 	name                  string
+	configSource          config.Provider
+	logger                logTypes.Logger
 	config                types.GinServerConfig
 	app                   *http.Server
 	baseRouter            *gin.Engine
@@ -68,6 +70,7 @@ func (s *GinServer) init(name string, serverConfig types.GinServerConfig, rawCon
 	}
 
 	s.baseRouter = gin.New()
+	s.baseRouter.Use(middlewares.ContextMiddleware())
 
 	s.groups = make(map[string]*gin.RouterGroup)
 	s.supportedMiddlewares = []string{
@@ -117,15 +120,13 @@ func (s *GinServer) attachMiddlewares(orders []string, rawConfig map[string]inte
 			case "logger":
 				{
 					// check which logger must be used
-					loggerType, err := config.GetManager().Get("logger", "type")
-					if err == nil {
-						if loggerType == "zap" {
-							s.baseRouter.Use(middlewares.ZapLogger())
-							s.baseRouter.Use(middlewares.ZapRecoveryLogger())
-						} else if loggerType == "logme" {
-							s.baseRouter.Use(middlewares.LogMeLogger())
-							s.baseRouter.Use(middlewares.LogMeRecoveryLogger())
-						}
+					switch l := logger.LoggerOrDefault(s.logger).(type) {
+					case *logger.ZapWrapper:
+						s.baseRouter.Use(middlewares.ZapLogger(l))
+						s.baseRouter.Use(middlewares.ZapRecoveryLogger(l))
+					case *logger.LogMeWrapper:
+						s.baseRouter.Use(middlewares.LogMeLogger(l))
+						s.baseRouter.Use(middlewares.LogMeRecoveryLogger(l))
 					}
 				}
 			case "favicon":
@@ -180,9 +181,15 @@ func (s *GinServer) addGroup(keyName string, groupName string, router *gin.Route
 // MARK: Public functions
 
 // NewGinServer - create a new instance of Server and return it
-func NewGinServer(name string, config types.GinServerConfig, rawConfig map[string]interface{}) (*GinServer, error) {
-	server := &GinServer{}
-	err := server.init(name, config, rawConfig)
+func NewGinServer(name string, serverConfig types.GinServerConfig, rawConfig map[string]interface{}) (*GinServer, error) {
+	return newGinServer(config.GetManager(), nil, name, serverConfig, rawConfig)
+}
+
+// newGinServer - create a new instance of Server that reads the other configs from the source and logs through
+// the logger, the nil logger is the logger of the default manager
+func newGinServer(source config.Provider, l logTypes.Logger, name string, serverConfig types.GinServerConfig, rawConfig map[string]interface{}) (*GinServer, error) {
+	server := &GinServer{configSource: source, logger: l}
+	err := server.init(name, serverConfig, rawConfig)
 	if err != nil {
 		return nil, NewCreateServerErr(err)
 	}
@@ -223,12 +230,12 @@ func (s *GinServer) Start() error {
 	err := <-errCh
 
 	if err == nil {
-		l, _ := logger.GetManager().GetLogger()
+		l := logger.LoggerOrDefault(s.logger)
 		if l != nil {
 			l.Log(logTypes.NewLogObject(logTypes.INFO, "http.Server.Start", HttpServerMaintenanceType, time.Now(), "Starting the Http server ...", s.config.ListenAddress))
 		}
 	} else {
-		l, _ := logger.GetManager().GetLogger()
+		l := logger.LoggerOrDefault(s.logger)
 		if l != nil {
 			l.Log(logTypes.NewLogObject(logTypes.ERROR, "http.Server.Start", HttpServerMaintenanceType, time.Now(), "Starting the Http server failed ...", err))
 		}
@@ -1,9 +1,9 @@
 package http
 
 import (
-	"encoding/json"
 	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/http/types"
+	logTypes "github.com/abolfazlbeh/zhycan/internal/logger/types"
 	"github.com/abolfazlbeh/zhycan/internal/utils"
 	"github.com/gin-gonic/gin"
 	"log"
@@ -15,10 +15,12 @@ import (
 // manager object
 type manager struct {
 	name             string
+	configSource     config.Provider
 	lock             sync.Mutex
 	servers          map[string]*GinServer
 	defaultServer    string
 	isServersStarted bool
+	logger           logTypes.Logger
 }
 
 // MARK: Module variables
@@ -38,7 +40,7 @@ func (m *manager) init() {
 	defer m.lock.Unlock()
 
 	// read configs and save it
-	serversCfg, err := config.GetManager().Get(m.name, "servers")
+	serversCfg, err := m.configSource.Get(m.name, "servers")
 	if err != nil {
 		return
 	}
@@ -48,46 +50,43 @@ func (m *manager) init() {
 		m.servers = make(map[string]*GinServer)
 	}
 
-	for _, item := range serversCfg.([]interface{}) {
-		jsonBody, err2 := json.Marshal(item)
-		if err2 != nil {
-			continue
-		}
-
-		var obj types.GinServerConfig
-		err := json.Unmarshal(jsonBody, &obj)
-		if err == nil {
-			//first check server existed -> if not exist -> create a new one
-			if server1, ok := m.servers[obj.Name]; ok {
-				// just update the server with new config
-				err1 := server1.UpdateConfigs(obj, item.(map[string]interface{}))
-				if err1 != nil {
-					server1.Stop()
-					delete(m.servers, obj.Name)
-					var k int
-					for i, v := range serverNames {
-						if v == obj.Name {
-							k = i
-							break
-						}
-					}
+	serverConfigs, err := config.DecodeFrom[[]types.GinServerConfig](m.configSource, m.name, "servers")
+	if err != nil {
+		return
+	}
 
-					if k >= 0 {
-						serverNames = append(serverNames[:k], serverNames[k+1:]...)
+	for i, item := range serversCfg.([]interface{}) {
+		obj := serverConfigs[i]
+		//first check server existed -> if not exist -> create a new one
+		if server1, ok := m.servers[obj.Name]; ok {
+			// just update the server with new config
+			err1 := server1.UpdateConfigs(obj, item.(map[string]interface{}))
+			if err1 != nil {
+				server1.Stop()
+				delete(m.servers, obj.Name)
+				var k int
+				for i, v := range serverNames {
+					if v == obj.Name {
+						k = i
+						break
 					}
 				}
-			} else {
-				server, err1 := NewGinServer(m.name, obj, item.(map[string]interface{}))
-				if err1 == nil {
-					m.servers[obj.Name] = server
 
-					serverNames = append(serverNames, obj.Name)
+				if k >= 0 {
+					serverNames = append(serverNames[:k], serverNames[k+1:]...)
 				}
 			}
+		} else {
+			server, err1 := newGinServer(m.configSource, m.logger, m.name, obj, item.(map[string]interface{}))
+			if err1 == nil {
+				m.servers[obj.Name] = server
+
+				serverNames = append(serverNames, obj.Name)
+			}
 		}
 	}
 
-	defaultS, err := config.GetManager().Get(m.name, "default")
+	defaultS, err := m.configSource.Get(m.name, "default")
 	if err == nil {
 		if utils.ArrayContains(&serverNames, defaultS.(string)) {
 			m.defaultServer = defaultS.(string)
@@ -96,21 +95,33 @@ func (m *manager) init() {
 		}
 	}
 
+	// the admin routes are opt-in and just served by the default server
+	adminConfig, err := config.DecodeOptionalFrom[types.AdminConfig](m.configSource, m.name, "admin")
+	if err == nil {
+		if server, ok := m.servers[m.defaultServer]; ok {
+			server.attachAdminRoutes(adminConfig)
+		}
+	}
+
 	m.isServersStarted = false
 }
 
 // restartOnChangeConfig - subscribe a function for when the config is changed
 func (m *manager) restartOnChangeConfig() {
 	// Config config server to reload
-	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	wrapper, err := m.configSource.GetConfigWrapper(m.name)
 	if err == nil {
-		wrapper.RegisterChangeCallback(func() interface{} {
-			if m.isServersStarted {
+		wrapper.Subscribe("", func(event config.ChangeEvent) {
+			if !m.isServersStarted {
+				return
+			}
+
+			// restart the servers just when their config is changed
+			if event.Has("servers") || event.Has("default") || event.Has("admin") {
 				m.StopServers()
 				m.init()
 				m.StartServers()
 			}
-			return nil
 		})
 	} else {
 		// TODO: make some logs
@@ -119,13 +130,25 @@ func (m *manager) restartOnChangeConfig() {
 
 // MARK: Public Functions
 
-// GetManager - This function returns singleton instance of Logger Manager
+// NewManager - create an independent Http Manager that reads its config from the source
+func NewManager(source config.Provider) *manager {
+	return NewManagerWithLogger(source, nil)
+}
+
+// NewManagerWithLogger - create an independent Http Manager that reads its config from the source and its servers
+// log through the logger, the nil logger is the logger of the default manager
+func NewManagerWithLogger(source config.Provider, l logTypes.Logger) *manager {
+	m := &manager{configSource: source, logger: l}
+	m.init()
+	m.restartOnChangeConfig()
+	return m
+}
+
+// GetManager - This function returns singleton instance of Http Manager
 func GetManager() *manager {
 	// once used for prevent race condition and manage critical section.
 	once.Do(func() {
-		managerInstance = &manager{}
-		managerInstance.init()
-		managerInstance.restartOnChangeConfig()
+		managerInstance = NewManager(config.GetManager())
 	})
 	return managerInstance
 }
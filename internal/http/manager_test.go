@@ -13,7 +13,7 @@ import (
 func TestManager_Init(t *testing.T) {
 	makeReadyConfigManager()
 
-	m := manager{}
+	m := manager{configSource: config.GetManager()}
 	m.init()
 
 	if m.name != "http" {
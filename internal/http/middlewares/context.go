@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"github.com/gin-gonic/gin"
+)
+
+// ContextMiddleware - put the request id and the trace of the request in its context, so the logs of the handlers,
+// the db queries and the outgoing calls can be correlated; the request id is sent back in the `X-Request-ID` header
+func ContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, requestID := types.ContextFromRequest(
+			c.Request.Context(),
+			c.GetHeader(types.RequestIDHeader),
+			c.GetHeader(types.TraceParentHeader),
+		)
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Set(types.RequestIDKey, requestID)
+		c.Header(types.RequestIDHeader, requestID)
+		c.Next()
+	}
+}
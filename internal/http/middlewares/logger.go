@@ -2,47 +2,51 @@ package middlewares
 
 import (
 	"github.com/abolfazlbeh/zhycan/internal/logger"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
 	"github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"time"
 )
 
-func ZapLogger() gin.HandlerFunc {
-	logge, err := logger.GetManager().GetLogger()
-	if err != nil {
-		return nil
-	}
-	logger1 := logge.(*logger.ZapWrapper).Instance()
+// ZapLogger - the middleware that writes through the instance of the logger
+func ZapLogger(logge *logger.ZapWrapper) gin.HandlerFunc {
+	logger1 := logge.Instance()
 
-	return ginzap.Ginzap(logger1, time.RFC3339, true)
+	return ginzap.GinzapWithConfig(logger1, &ginzap.Config{
+		TimeFormat: time.RFC3339,
+		UTC:        true,
+		Context:    contextFields,
+	})
 }
 
-func ZapRecoveryLogger() gin.HandlerFunc {
-	logge, err := logger.GetManager().GetLogger()
-	if err != nil {
-		return nil
-	}
-	logger1 := logge.(*logger.ZapWrapper).Instance()
+// ZapRecoveryLogger - the middleware that writes through the instance of the logger
+func ZapRecoveryLogger(logge *logger.ZapWrapper) gin.HandlerFunc {
+	logger1 := logge.Instance()
 
 	return ginzap.RecoveryWithZap(logger1, true)
 }
 
-func LogMeLogger() gin.HandlerFunc {
-	logge, err := logger.GetManager().GetLogger()
-	if err != nil {
-		return nil
-	}
-	logger1 := logge.(*logger.LogMeWrapper).Instance()
+// LogMeLogger - the middleware that writes through the instance of the logger
+func LogMeLogger(logge *logger.LogMeWrapper) gin.HandlerFunc {
+	logger1 := logge.Instance()
 
 	return gin.LoggerWithWriter(logger1.Writer())
 }
 
-func LogMeRecoveryLogger() gin.HandlerFunc {
-	logge, err := logger.GetManager().GetLogger()
-	if err != nil {
-		return nil
-	}
-	logger1 := logge.(*logger.LogMeWrapper).Instance()
+// LogMeRecoveryLogger - the middleware that writes through the instance of the logger
+func LogMeRecoveryLogger(logge *logger.LogMeWrapper) gin.HandlerFunc {
+	logger1 := logge.Instance()
 
 	return gin.RecoveryWithWriter(logger1.Writer())
 }
+
+// contextFields - returns the fields that the context of the request carries, e.g. the request id
+func contextFields(c *gin.Context) []zapcore.Field {
+	var fields []zapcore.Field
+	for _, f := range types.FieldsFromContext(c.Request.Context()) {
+		fields = append(fields, zap.Any(f.Key, f.Value))
+	}
+	return fields
+}
@@ -0,0 +1,12 @@
+package http
+
+// Imports needed list
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/http/types"
+)
+
+// Schema - returns the JSON Schema of the `http` config module
+func Schema() *config.Schema {
+	return config.ModuleSchema("http", config.SchemaOf(types.Config{}))
+}
@@ -56,9 +56,25 @@ type FaviconMiddlewareConfig struct {
 	CacheControl string `json:"cache_control"`
 }
 
+// Config - the structure of the `http` config module
+type Config struct {
+	Default string            `json:"default"`
+	Servers []GinServerConfig `json:"servers" validate:"required,dive"`
+	Admin   AdminConfig       `json:"admin"`
+}
+
+// AdminConfig - the opt-in admin routes that are registered on the default server, they need the bearer token
+// and without the token they are just served to the localhost clients
+type AdminConfig struct {
+	Enabled    bool   `json:"enabled"`
+	ConfigPath string `json:"config_path" default:"/_zhycan/config"`
+	LoggerPath string `json:"logger_path" default:"/_zhycan/logger/levels"`
+	Token      string `json:"token"`
+}
+
 type GinServerConfig struct {
-	ListenAddress string   `json:"addr"`
-	Name          string   `json:"name"`
+	ListenAddress string   `json:"addr" validate:"required"`
+	Name          string   `json:"name" validate:"required"`
 	Versions      []string `json:"versions"`
 	SupportStatic bool     `json:"support_static"`
 	Config        struct {
@@ -0,0 +1,187 @@
+package logger
+
+// Imports needed list
+import (
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// MARK: TestingT
+
+// TestingT - the part of `*testing.T` that the assertions of the capture logger need
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// MARK: CaptureLogger
+
+// CaptureLogger - the logger of the tests that keeps the logs in memory, so the tests can assert on them.
+// The logs are recorded synchronously after the secrets are redacted, the same as the other loggers.
+type CaptureLogger struct {
+	entries     []types.LogObject
+	initialized bool
+	lock        sync.Mutex
+}
+
+// NewCaptureLogger - create the initialized capture logger
+func NewCaptureLogger() *CaptureLogger {
+	l := &CaptureLogger{}
+	_ = l.Constructor("logger")
+	return l
+}
+
+// Constructor - It initializes the logger
+func (l *CaptureLogger) Constructor(name string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.initialized = true
+	return nil
+}
+
+// Close - nothing to close, the logs are kept for the assertions
+func (l *CaptureLogger) Close() {}
+
+// Log - record a copy of the log object
+func (l *CaptureLogger) Log(obj *types.LogObject) {
+	entry := *obj
+	entry.Message = config.RedactSecretValue(obj.Message)
+	entry.Additional = config.RedactSecretValue(obj.Additional)
+	entry.Fields = redactFields(append([]types.Field{}, obj.Fields...))
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+// IsInitialized - that returns boolean value whether it's initialized
+func (l *CaptureLogger) IsInitialized() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.initialized
+}
+
+// Sync - the logs are recorded synchronously, so there is nothing to flush
+func (l *CaptureLogger) Sync() {}
+
+// Entries - returns a copy of the recorded logs in order
+func (l *CaptureLogger) Entries() []types.LogObject {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	result := make([]types.LogObject, len(l.entries))
+	copy(result, l.entries)
+	return result
+}
+
+// Reset - remove the recorded logs
+func (l *CaptureLogger) Reset() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.entries = nil
+}
+
+// Find - returns the recorded logs that the function matches
+func (l *CaptureLogger) Find(match func(obj types.LogObject) bool) []types.LogObject {
+	var result []types.LogObject
+	for _, item := range l.Entries() {
+		if match(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Logged - returns the recorded logs with the level and the module that their message contains the text,
+// the empty module matches every module
+func (l *CaptureLogger) Logged(level types.LogLevel, module string, substr string) []types.LogObject {
+	return l.Find(func(obj types.LogObject) bool {
+		return obj.Level == level &&
+			(module == "" || obj.Module == module) &&
+			strings.Contains(fmt.Sprintf("%v", obj.Message), substr)
+	})
+}
+
+// LoggedWithType - returns the recorded logs of the log type that their message contains the text
+func (l *CaptureLogger) LoggedWithType(logType types.LogType, substr string) []types.LogObject {
+	return l.Find(func(obj types.LogObject) bool {
+		return obj.LogType == logType.String() && strings.Contains(fmt.Sprintf("%v", obj.Message), substr)
+	})
+}
+
+// LoggedWithField - returns the recorded logs of the module that have the field with the value,
+// the value is compared with the plain value of the field, e.g. the message of the errors
+func (l *CaptureLogger) LoggedWithField(module string, key string, value interface{}) []types.LogObject {
+	return l.Find(func(obj types.LogObject) bool {
+		if module != "" && obj.Module != module {
+			return false
+		}
+		for _, f := range obj.Fields {
+			if f.Key == key && (reflect.DeepEqual(f.Value, value) || reflect.DeepEqual(f.Plain(), value)) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// AssertLogged - report an error if no log with the level and the module contains the text
+func (l *CaptureLogger) AssertLogged(t TestingT, level types.LogLevel, module string, substr string) bool {
+	t.Helper()
+	if len(l.Logged(level, module, substr)) == 0 {
+		t.Errorf("Expected a %v log of the module `%v` that contains %q, but got:\n%v", level, module, substr, l.dump())
+		return false
+	}
+	return true
+}
+
+// AssertNotLogged - report an error if a log with the level and the module contains the text
+func (l *CaptureLogger) AssertNotLogged(t TestingT, level types.LogLevel, module string, substr string) bool {
+	t.Helper()
+	if found := l.Logged(level, module, substr); len(found) > 0 {
+		t.Errorf("Expected no %v log of the module `%v` that contains %q, but got %v", level, module, substr, found)
+		return false
+	}
+	return true
+}
+
+// AssertLoggedWithType - report an error if no log of the log type contains the text
+func (l *CaptureLogger) AssertLoggedWithType(t TestingT, logType types.LogType, substr string) bool {
+	t.Helper()
+	if len(l.LoggedWithType(logType, substr)) == 0 {
+		t.Errorf("Expected a log of the type `%v` that contains %q, but got:\n%v", logType, substr, l.dump())
+		return false
+	}
+	return true
+}
+
+// AssertLoggedWithField - report an error if no log of the module has the field with the value
+func (l *CaptureLogger) AssertLoggedWithField(t TestingT, module string, key string, value interface{}) bool {
+	t.Helper()
+	if len(l.LoggedWithField(module, key, value)) == 0 {
+		t.Errorf("Expected a log of the module `%v` with the field %v=%v, but got:\n%v", module, key, value, l.dump())
+		return false
+	}
+	return true
+}
+
+// MARK: Private Methods
+
+// dump - returns the recorded logs one per line for the messages of the failed assertions
+func (l *CaptureLogger) dump() string {
+	entries := l.Entries()
+	if len(entries) == 0 {
+		return "  (no logs)"
+	}
+
+	lines := make([]string, len(entries))
+	for i, item := range entries {
+		lines[i] = fmt.Sprintf("  %v (%v/%v) - %v%v", item.Level, item.LogType, item.Module, item.Message, fieldsSuffix(item.Fields))
+	}
+	return strings.Join(lines, "\n")
+}
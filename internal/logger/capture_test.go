@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeT - records the failures of the assertions
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func Test_CaptureLoggerAssertions(t *testing.T) {
+	capture := NewCaptureLogger()
+	capture.Log(types.NewLogObject(types.ERROR, "db", types.AppType, time.Now(), "query failed", nil).WithFields(
+		types.Err(errors.New("timeout")), types.Int("rows", 3),
+	))
+	capture.Log(types.NewLogObject(types.INFO, "http", types.FuncMaintenanceType, time.Now(), "request served", nil))
+
+	capture.AssertLogged(t, types.ERROR, "db", "failed")
+	capture.AssertLogged(t, types.INFO, "", "served")
+	capture.AssertNotLogged(t, types.ERROR, "http", "")
+	capture.AssertLoggedWithType(t, types.FuncMaintenanceType, "request")
+	capture.AssertLoggedWithField(t, "db", "error", "timeout")
+	capture.AssertLoggedWithField(t, "db", "rows", int64(3))
+
+	f := &fakeT{}
+	capture.AssertLogged(f, types.DEBUG, "db", "failed")
+	capture.AssertNotLogged(f, types.ERROR, "db", "query")
+	capture.AssertLoggedWithField(f, "http", "error", "timeout")
+	if len(f.errors) != 3 {
+		t.Fatalf("Failed assertions --> Expected: %v, but got %v", 3, f.errors)
+	}
+	if !strings.Contains(f.errors[0], "ERROR (APP/db) - query failed") {
+		t.Errorf("Failure message --> Expected the recorded logs, but got %v", f.errors[0])
+	}
+
+	capture.Reset()
+	if len(capture.Entries()) != 0 {
+		t.Errorf("Entries after reset --> Expected: %v, but got %v", 0, len(capture.Entries()))
+	}
+}
+
+func Test_GetManagerWithoutConfig(t *testing.T) {
+	if config.GetManager() != nil {
+		t.Skip("the config manager is created by another test")
+	}
+
+	managerLock.Lock()
+	previous, previousFallback := managerInstance, fallbackManager
+	managerInstance, fallbackManager = nil, nil
+	managerLock.Unlock()
+	t.Cleanup(func() {
+		managerLock.Lock()
+		managerInstance, fallbackManager = previous, previousFallback
+		managerLock.Unlock()
+	})
+
+	m := GetManager()
+	if GetManager() != m {
+		t.Errorf("Fallback manager of the calls --> Expected: %v, but got %v", m, GetManager())
+	}
+	if managerInstance != nil {
+		t.Errorf("Cached manager without config --> Expected: %v, but got %v", nil, managerInstance)
+	}
+}
+
+func Test_ManagerInvalidConfig(t *testing.T) {
+	for _, loggerType := range []string{"zap", "logme"} {
+		source, err := config.FromMap(map[string]map[string]interface{}{
+			"base":   {"name": "invalid"},
+			"logger": {"type": loggerType, "outputs": []interface{}{}, "channel_size": "many"},
+		})
+		if err != nil {
+			t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+		}
+
+		m := NewManager(source)
+		l, logErr := m.GetLogger()
+		if logErr == nil || l != nil {
+			t.Errorf("Logger `%v` of the invalid config --> Expected an error, but got %v", loggerType, l)
+		}
+	}
+}
+
+func Test_ManagerReplaceLogger(t *testing.T) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base":   {"name": "capture"},
+		"logger": {"type": "logme", "outputs": []interface{}{}},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	m := NewManager(source)
+	original, _ := m.GetLogger()
+
+	capture := NewCaptureLogger()
+	restore := m.ReplaceLogger(capture)
+
+	l, _ := m.GetLogger()
+	l.Log(types.NewLogObject(types.WARNING, "tester", types.AppType, time.Now(), "captured", nil))
+	capture.AssertLogged(t, types.WARNING, "tester", "captured")
+
+	restore()
+	if l, _ := m.GetLogger(); l != original {
+		t.Errorf("Logger after restoring --> Expected: %v, but got %v", original, l)
+	}
+}
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"context"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_ContextFromRequest(t *testing.T) {
+	ctx, requestID := types.ContextFromRequest(context.Background(), "req-1", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if requestID != "req-1" {
+		t.Errorf("Request id --> Expected: %v, but got %v", "req-1", requestID)
+	}
+
+	expected := []types.Field{
+		types.String(types.RequestIDKey, "req-1"),
+		types.String(types.TraceIDKey, "4bf92f3577b34da6a3ce929d0e0e4736"),
+		types.String(types.SpanIDKey, "00f067aa0ba902b7"),
+	}
+	if got := types.FieldsFromContext(ctx); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Context fields --> Expected: %v, but got %v", expected, got)
+	}
+
+	ctx, requestID = types.ContextFromRequest(context.Background(), "", "00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+	if len(requestID) != 32 || types.ContextValue(ctx, types.RequestIDKey) != requestID {
+		t.Errorf("Generated request id --> Expected a 32 characters id, but got %v", requestID)
+	}
+	if types.ContextValue(ctx, types.TraceIDKey) != "" {
+		t.Errorf("Invalid trace parent --> Expected no trace id, but got %v", types.ContextValue(ctx, types.TraceIDKey))
+	}
+}
+
+func Test_LogObjectWithContext(t *testing.T) {
+	ctx := types.WithRequestID(context.Background(), "req-1")
+	ctx = types.WithUser(ctx, "u1")
+	ctx = types.WithUser(ctx, "u2")
+
+	obj := types.NewLogObject(types.INFO, "tester", types.AppType, time.Now(), "msg", nil).
+		WithFields(types.String(types.RequestIDKey, "own")).
+		WithContext(ctx)
+
+	expected := []types.Field{
+		types.String(types.UserKey, "u2"),
+		types.String(types.RequestIDKey, "own"),
+	}
+	if !reflect.DeepEqual(obj.Fields, expected) {
+		t.Errorf("Log object fields --> Expected: %v, but got %v", expected, obj.Fields)
+	}
+
+	obj = types.NewLogObject(types.INFO, "tester", types.AppType, time.Now(), "msg", nil).WithContext(nil)
+	if len(obj.Fields) != 0 {
+		t.Errorf("Log object fields of nil context --> Expected: %v, but got %v", 0, len(obj.Fields))
+	}
+}
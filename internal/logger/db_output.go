@@ -0,0 +1,204 @@
+package logger
+
+// Imports needed list
+import (
+	"context"
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	"log"
+	"sync"
+	"time"
+)
+
+// MARK: Constants
+
+const (
+	// dbOutputTimeout - the time that inserting one batch or purging the old logs can take
+	dbOutputTimeout = 10 * time.Second
+)
+
+// MARK: dbOutput
+
+// dbOutput - insert the logs into the sql or mongo connection in batches and purge the old ones in the background
+type dbOutput struct {
+	serviceName   string
+	sqlDb         *gorm.DB
+	collection    *mongo.Collection
+	batchSize     int
+	flushInterval time.Duration
+	retention     time.Duration
+	purgeInterval time.Duration
+	buffer        []types.ZhycanLog
+	stopCh        chan struct{}
+	wg            sync.WaitGroup
+	stopOnce      sync.Once
+	lock          sync.Mutex
+	flushLock     sync.Mutex
+}
+
+// newDbOutput - create the db output on the sql or the mongo connection and start its background jobs
+func newDbOutput(serviceName string, cfg types.DbOutputConfig, sqlDb *gorm.DB, mongoDb *mongo.Database) (*dbOutput, error) {
+	o := &dbOutput{
+		serviceName:   serviceName,
+		batchSize:     cfg.BatchSize,
+		flushInterval: cfg.FlushInterval,
+		retention:     cfg.Retention,
+		purgeInterval: cfg.PurgeInterval,
+		stopCh:        make(chan struct{}),
+	}
+	if o.batchSize < 1 {
+		o.batchSize = 1
+	}
+
+	switch cfg.Type {
+	case "sql":
+		if sqlDb == nil {
+			return nil, fmt.Errorf("the sql connection `%v` is not available", cfg.Use)
+		}
+		err := sqlDb.AutoMigrate(&types.ZhycanLog{})
+		if err != nil {
+			return nil, fmt.Errorf("cannot migrate the `ZhycanLog` table: %v", err)
+		}
+		o.sqlDb = sqlDb
+	case "mongo":
+		if mongoDb == nil {
+			return nil, fmt.Errorf("the mongo connection `%v` is not available", cfg.Use)
+		}
+		o.collection = mongoDb.Collection(cfg.Collection)
+	default:
+		return nil, fmt.Errorf("the db type `%v` is not supported", cfg.Type)
+	}
+
+	if o.flushInterval > 0 {
+		o.wg.Add(1)
+		go o.every(o.flushInterval, o.flush)
+	}
+	if o.retention > 0 && o.purgeInterval > 0 {
+		o.wg.Add(1)
+		go o.every(o.purgeInterval, func() {
+			_, err := o.purge(time.Now().Add(-o.retention))
+			if err != nil {
+				log.Printf("Cannot purge the old logs of the db output: %v", err)
+			}
+		})
+	}
+	return o, nil
+}
+
+// write - add the log to the batch, the batch is inserted when it is full
+func (o *dbOutput) write(c *types.LogObject) {
+	row := types.ZhycanLog{
+		Model:       gorm.Model{},
+		ServiceName: o.serviceName,
+		Level:       c.Level.String(),
+		LogType:     c.LogType,
+		Module:      c.Module,
+		Message:     fmt.Sprintf("%v", c.Message),
+		Additional:  fmt.Sprintf("%v", c.Additional),
+		Fields:      fieldsJSON(c.Fields),
+		LogTime:     c.Time,
+	}
+
+	o.lock.Lock()
+	o.buffer = append(o.buffer, row)
+	full := len(o.buffer) >= o.batchSize
+	o.lock.Unlock()
+
+	if full {
+		o.flush()
+	}
+}
+
+// flush - insert the logs of the batch
+func (o *dbOutput) flush() {
+	o.flushLock.Lock()
+	defer o.flushLock.Unlock()
+
+	o.lock.Lock()
+	rows := o.buffer
+	o.buffer = nil
+	o.lock.Unlock()
+
+	if len(rows) == 0 {
+		return
+	}
+
+	err := o.insert(rows)
+	if err != nil {
+		log.Printf("Cannot insert %v logs into the db output: %v", len(rows), err)
+	}
+}
+
+// purge - remove the logs older than the time, it returns the number of the removed logs
+func (o *dbOutput) purge(before time.Time) (int64, error) {
+	if o.sqlDb != nil {
+		result := o.sqlDb.Unscoped().Where("log_time < ?", before.UTC().UnixNano()).Delete(&types.ZhycanLog{})
+		return result.RowsAffected, result.Error
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbOutputTimeout)
+	defer cancel()
+	result, err := o.collection.DeleteMany(ctx, bson.M{"log_time": bson.M{"$lt": before.UTC().UnixNano()}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// Close - stop the background jobs and insert the remained logs
+func (o *dbOutput) Close() {
+	o.stopOnce.Do(func() {
+		close(o.stopCh)
+		o.wg.Wait()
+		o.flush()
+	})
+}
+
+// MARK: Private Methods
+
+// insert - insert the rows in one batch
+func (o *dbOutput) insert(rows []types.ZhycanLog) error {
+	if o.sqlDb != nil {
+		return o.sqlDb.CreateInBatches(&rows, o.batchSize).Error
+	}
+
+	docs := make([]interface{}, len(rows))
+	now := time.Now().UTC()
+	for i, row := range rows {
+		docs[i] = bson.M{
+			"service_name": row.ServiceName,
+			"level":        row.Level,
+			"log_type":     row.LogType,
+			"module":       row.Module,
+			"message":      row.Message,
+			"additional":   row.Additional,
+			"fields":       row.Fields,
+			"log_time":     row.LogTime,
+			"created_at":   now,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbOutputTimeout)
+	defer cancel()
+	_, err := o.collection.InsertMany(ctx, docs)
+	return err
+}
+
+// every - run the job on every interval until the output is closed
+func (o *dbOutput) every(interval time.Duration, job func()) {
+	defer o.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			job()
+		case <-o.stopCh:
+			return
+		}
+	}
+}
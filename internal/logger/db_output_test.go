@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_DbOutputBatches(t *testing.T) {
+	sqlDb, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "logs.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Opening the sqlite db --> Expected: %v, but got %v", nil, err)
+	}
+
+	cfg := types.DbOutputConfig{Use: "logs", Type: "sql", BatchSize: 3, FlushInterval: time.Hour}
+	output, err := newDbOutput("audit", cfg, sqlDb, nil)
+	if err != nil {
+		t.Fatalf("Creating the db output --> Expected: %v, but got %v", nil, err)
+	}
+
+	count := func() int64 {
+		var n int64
+		sqlDb.Model(&types.ZhycanLog{}).Count(&n)
+		return n
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+	output.write(types.NewLogObject(types.INFO, "tester", types.AppType, old, "old", nil))
+	output.write(types.NewLogObject(types.INFO, "tester", types.AppType, time.Now(), "first", nil).WithFields(types.String("user", "u1")))
+	if count() != 0 {
+		t.Errorf("Rows before the batch is full --> Expected: %v, but got %v", 0, count())
+	}
+
+	output.write(types.NewLogObject(types.ERROR, "tester", types.AppType, time.Now(), "second", nil))
+	if count() != 3 {
+		t.Errorf("Rows after the batch is full --> Expected: %v, but got %v", 3, count())
+	}
+
+	// the remained logs are inserted on closing
+	output.write(types.NewLogObject(types.DEBUG, "tester", types.AppType, time.Now(), "last", nil))
+	output.Close()
+	if count() != 4 {
+		t.Errorf("Rows after closing --> Expected: %v, but got %v", 4, count())
+	}
+
+	var row types.ZhycanLog
+	sqlDb.Where("message = ?", "first").First(&row)
+	if row.ServiceName != "audit" || row.Level != "INFO" || row.Fields != `{"user":"u1"}` {
+		t.Errorf("Inserted row --> Expected: %v, but got %v", "audit INFO user=u1", row)
+	}
+
+	purged, err := output.purge(time.Now().Add(-24 * time.Hour))
+	if err != nil || purged != 1 || count() != 3 {
+		t.Errorf("Purging the old rows --> Expected: %v, but got %v (%v)", 1, purged, err)
+	}
+}
+
+func Test_DbOutputUnavailableConnection(t *testing.T) {
+	_, err := newDbOutput("audit", types.DbOutputConfig{Use: "missing", Type: "mongo"}, nil, nil)
+	if err == nil {
+		t.Errorf("Creating the db output without the connection --> Expected an error, but got %v", err)
+	}
+}
+
+func Test_DbOutputOfConfigSource(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "source.db")
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "source"},
+		"db": {
+			"connections": []interface{}{"logs"},
+			"logs":        map[string]interface{}{"type": "sqlite", "db": dbFile},
+		},
+		"logger": {
+			"type":    "logme",
+			"outputs": []interface{}{"db"},
+			"db":      map[string]interface{}{"use": "logs", "batch_size": 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	m := NewManager(source)
+	l, logErr := m.GetLogger()
+	if logErr != nil {
+		t.Fatalf("Creating the logger --> Expected: %v, but got %v", nil, logErr)
+	}
+	l.Log(types.NewLogObject(types.INFO, "tester", types.AppType, time.Now(), "to the source db", nil))
+	l.Close()
+
+	sqlDb, err := gorm.Open(sqlite.Open(dbFile), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Opening the sqlite db --> Expected: %v, but got %v", nil, err)
+	}
+	var n int64
+	sqlDb.Model(&types.ZhycanLog{}).Where("message = ?", "to the source db").Count(&n)
+	if n != 1 {
+		t.Errorf("Rows in the db of the config source --> Expected: %v, but got %v", 1, n)
+	}
+}
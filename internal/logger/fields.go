@@ -0,0 +1,86 @@
+package logger
+
+// Imports needed list
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"strings"
+	"time"
+)
+
+// MARK: Private Functions
+
+// redactFields - returns a copy of the fields that the resolved secrets of the configs are redacted from their values
+func redactFields(fields []types.Field) []types.Field {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	result := make([]types.Field, len(fields))
+	for i, f := range fields {
+		result[i] = types.Field{Key: f.Key, Value: config.RedactSecretValue(f.Value)}
+	}
+	return result
+}
+
+// zapFields - convert the fields to the native zap fields, so their types are kept in the json output
+func zapFields(fields []types.Field) []zapcore.Field {
+	result := make([]zapcore.Field, 0, len(fields))
+	for _, f := range fields {
+		switch v := f.Value.(type) {
+		case nil:
+			result = append(result, zap.Skip())
+		case string:
+			result = append(result, zap.String(f.Key, v))
+		case int64:
+			result = append(result, zap.Int64(f.Key, v))
+		case float64:
+			result = append(result, zap.Float64(f.Key, v))
+		case bool:
+			result = append(result, zap.Bool(f.Key, v))
+		case time.Duration:
+			result = append(result, zap.Duration(f.Key, v))
+		case time.Time:
+			result = append(result, zap.Time(f.Key, v))
+		case error:
+			result = append(result, zap.NamedError(f.Key, v))
+		default:
+			result = append(result, zap.Any(f.Key, v))
+		}
+	}
+	return result
+}
+
+// fieldsText - returns the fields in the form of `key=value` pairs for the text outputs
+func fieldsText(fields []types.Field) string {
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		parts = append(parts, fmt.Sprintf("%s=%v", f.Key, f.Plain()))
+	}
+	return strings.Join(parts, " ")
+}
+
+// fieldsSuffix - returns the fields to be appended to the line of the text outputs, it is empty without fields
+func fieldsSuffix(fields []types.Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	return " ... " + fieldsText(fields)
+}
+
+// fieldsJSON - returns the fields as a json object for the db output, so they can be queried
+func fieldsJSON(fields []types.Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(types.FieldsMap(fields))
+	if err != nil {
+		return fmt.Sprintf("%v", types.FieldsMap(fields))
+	}
+	return string(data)
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_ZapStructuredFields(t *testing.T) {
+	dir := t.TempDir()
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "fields"},
+		"logger": {
+			"type":    "zap",
+			"outputs": []interface{}{"file"},
+			"file":    map[string]interface{}{"level": "debug", "path": dir},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	logg := &ZapWrapper{configSource: source}
+	err = logg.Constructor("logger")
+	if err != nil {
+		t.Fatalf("Creating logger --> Expected: %v, but got %v", nil, err)
+	}
+
+	logg.Log(types.NewLogObject(types.INFO, "tester", types.AppType, time.Now(), "user created", nil).WithFields(
+		types.String("user", "u1"),
+		types.Int("n", 3),
+		types.Bool("admin", false),
+		types.Err(errors.New("boom")),
+	))
+
+	var line map[string]interface{}
+	for i := 0; i < 50 && line == nil; i++ {
+		time.Sleep(20 * time.Millisecond)
+		logg.Sync()
+
+		file, err := os.Open(filepath.Join(dir, "fields.log"))
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		if scanner.Scan() {
+			_ = json.Unmarshal(scanner.Bytes(), &line)
+		}
+		_ = file.Close()
+	}
+
+	if line == nil {
+		t.Fatalf("Reading the log line --> Expected a json line, but got nothing")
+	}
+	if line["user"] != "u1" {
+		t.Errorf("String field --> Expected: %v, but got %v", "u1", line["user"])
+	}
+	if line["n"] != float64(3) {
+		t.Errorf("Int field --> Expected: %v, but got %v", 3, line["n"])
+	}
+	if line["admin"] != false {
+		t.Errorf("Bool field --> Expected: %v, but got %v", false, line["admin"])
+	}
+	if line["error"] != "boom" {
+		t.Errorf("Error field --> Expected: %v, but got %v", "boom", line["error"])
+	}
+}
+
+func Test_FieldsJSON(t *testing.T) {
+	fields := []types.Field{
+		types.String("user", "u1"),
+		types.Int64("n", 3),
+		types.Duration("took", time.Second),
+		types.Err(nil),
+	}
+
+	expected := `{"error":null,"n":3,"took":"1s","user":"u1"}`
+	if got := fieldsJSON(fields); got != expected {
+		t.Errorf("Fields json --> Expected: %v, but got %v", expected, got)
+	}
+
+	expectedText := " ... user=u1 n=3 took=1s error=<nil>"
+	if got := fieldsSuffix(fields); got != expectedText {
+		t.Errorf("Fields text --> Expected: %v, but got %v", expectedText, got)
+	}
+	if got := fieldsSuffix(nil); got != "" {
+		t.Errorf("Empty fields text --> Expected: %v, but got %v", "", got)
+	}
+}
@@ -0,0 +1,161 @@
+package logger
+
+// Imports needed list
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// MARK: Constants
+
+const (
+	// gelfChunkHeaderSize - the size of the header of every GELF chunk: magic bytes, message id, sequence number and count
+	gelfChunkHeaderSize = 12
+	// gelfMaxChunks - the maximum number of the chunks of one GELF message
+	gelfMaxChunks = 128
+)
+
+// MARK: Variables
+
+// gelfFieldRegexp - the characters that are not allowed in the names of the GELF additional fields
+var gelfFieldRegexp = regexp.MustCompile(`[^\w.\-]`)
+
+// MARK: gelfEncoder
+
+// gelfEncoder - encode the records in GELF 1.1
+type gelfEncoder struct {
+	host        string
+	protocol    string
+	compression string
+	chunkSize   int
+	stdout      io.Writer
+}
+
+// encode - returns the message as one null-terminated packet over tcp,
+// and as the compressed datagram (chunked if it is bigger than the chunk size) over udp
+func (e *gelfEncoder) encode(r remoteRecord) ([][]byte, error) {
+	message := map[string]interface{}{
+		"version":       "1.1",
+		"host":          e.host,
+		"short_message": r.Message,
+		"timestamp":     float64(r.Time.UnixNano()) / 1e9,
+		"level":         syslogSeverity(r.Level),
+	}
+	for k, v := range r.Fields {
+		message[gelfFieldName(k)] = gelfFieldValue(v)
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	if e.stdout != nil {
+		_, _ = e.stdout.Write(append(data, '\n'))
+	}
+
+	if e.protocol == "tcp" {
+		// the compression is not supported over tcp
+		return [][]byte{append(data, 0)}, nil
+	}
+
+	data, err = gelfCompress(data, e.compression)
+	if err != nil {
+		return nil, err
+	}
+	return gelfChunks(data, e.chunkSize)
+}
+
+// MARK: Private Functions
+
+// gelfFieldName - returns the name of the additional field, `id` is reserved so it is renamed
+func gelfFieldName(key string) string {
+	key = gelfFieldRegexp.ReplaceAllString(key, "_")
+	if key == "id" {
+		key = "id_"
+	}
+	return "_" + key
+}
+
+// gelfFieldValue - the values of the additional fields must be strings or numbers
+func gelfFieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string, float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return v
+	case nil:
+		return ""
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// gelfCompress - compress the message by gzip or zlib
+func gelfCompress(data []byte, compression string) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch compression {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "zlib":
+		w = zlib.NewWriter(&buf)
+	default:
+		return data, nil
+	}
+
+	_, err := w.Write(data)
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gelfChunks - split the datagram into the GELF chunks if it is bigger than the chunk size
+func gelfChunks(data []byte, chunkSize int) ([][]byte, error) {
+	if len(data) <= chunkSize {
+		return [][]byte{data}, nil
+	}
+
+	payloadSize := chunkSize - gelfChunkHeaderSize
+	count := (len(data) + payloadSize - 1) / payloadSize
+	if count > gelfMaxChunks {
+		return nil, fmt.Errorf("the message needs %d chunks, more than %d", count, gelfMaxChunks)
+	}
+
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * payloadSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		chunk := make([]byte, 0, gelfChunkHeaderSize+end-i*payloadSize)
+		chunk = append(chunk, 0x1e, 0x0f)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, data[i*payloadSize:end]...)
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
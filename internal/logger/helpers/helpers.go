@@ -1,10 +1,47 @@
 package helpers
 
 import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/db"
+	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
+	"sync"
 )
 
-func GetSqlDbInstance(instanceName string) (*gorm.DB, error) {
-	return db.GetManager().GetDb(instanceName)
+// dbManager - the part of the db manager that the logger needs
+type dbManager interface {
+	GetDb(instanceName string) (*gorm.DB, error)
+	GetMongoDb(instanceName string) (*mongo.Database, error)
+}
+
+// MARK: Module variables
+var managersLock sync.Mutex
+var managers = map[config.Provider]dbManager{}
+
+// getDbManager - returns the db manager of the config source, the default config uses the default db manager
+// and every other source gets its own one that is shared by its loggers
+func getDbManager(source config.Provider) dbManager {
+	if source == nil || source == config.Provider(config.GetManager()) {
+		return db.GetManager()
+	}
+
+	managersLock.Lock()
+	defer managersLock.Unlock()
+
+	if m, ok := managers[source]; ok {
+		return m
+	}
+	m := db.NewManager(source)
+	managers[source] = m
+	return m
+}
+
+// GetSqlDbInstance - returns the sql connection with the name of the `db` module of the config source
+func GetSqlDbInstance(source config.Provider, instanceName string) (*gorm.DB, error) {
+	return getDbManager(source).GetDb(instanceName)
+}
+
+// GetMongoDbInstance - returns the mongo database with the name of the `db` module of the config source
+func GetMongoDbInstance(source config.Provider, instanceName string) (*mongo.Database, error) {
+	return getDbManager(source).GetMongoDb(instanceName)
 }
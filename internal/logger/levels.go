@@ -0,0 +1,252 @@
+package logger
+
+// Imports needed list
+import (
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// MARK: levelController
+
+// moduleLevels - the snapshot of the levels of the modules, it is replaced as a whole on every change
+type moduleLevels struct {
+	levels map[string]types.LogLevel
+	// verbose - the most verbose level of the modules, so the outputs can skip the logs that no one wants
+	verbose types.LogLevel
+}
+
+// levelController - the levels of the outputs and the modules that can be changed at runtime.
+// Every output has an atomic level, and the level of a module replaces the levels of the outputs for its logs.
+type levelController struct {
+	outputs map[string]zap.AtomicLevel
+	modules atomic.Value
+	lock    sync.RWMutex
+}
+
+// newLevelController - create the controller with the levels of the modules in the config
+func newLevelController(modules map[string]string) *levelController {
+	c := &levelController{outputs: make(map[string]zap.AtomicLevel)}
+	c.SetModuleLevels(parseModuleLevels(modules))
+	return c
+}
+
+// register - add the output with its level and returns its atomic level
+func (c *levelController) register(output string, level types.LogLevel) zap.AtomicLevel {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	atomicLevel := zap.NewAtomicLevelAt(logLevelToZap(level))
+	c.outputs[output] = atomicLevel
+	return atomicLevel
+}
+
+// SetOutputLevel - change the level of the output
+func (c *levelController) SetOutputLevel(output string, level types.LogLevel) error {
+	c.lock.RLock()
+	atomicLevel, ok := c.outputs[output]
+	c.lock.RUnlock()
+
+	if !ok {
+		return NewError(fmt.Errorf("log output `%v` is not active", output))
+	}
+	atomicLevel.SetLevel(logLevelToZap(level))
+	return nil
+}
+
+// SetModuleLevel - change the level of the module and its sub-modules
+func (c *levelController) SetModuleLevel(module string, level types.LogLevel) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	levels := c.moduleLevels()
+	levels[module] = level
+	c.storeModuleLevels(levels)
+}
+
+// ClearModuleLevel - remove the level of the module, so the levels of the outputs are used again
+func (c *levelController) ClearModuleLevel(module string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	levels := c.moduleLevels()
+	delete(levels, module)
+	c.storeModuleLevels(levels)
+}
+
+// SetModuleLevels - replace the levels of all modules
+func (c *levelController) SetModuleLevels(levels map[string]types.LogLevel) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	result := make(map[string]types.LogLevel, len(levels))
+	for k, v := range levels {
+		result[k] = v
+	}
+	c.storeModuleLevels(result)
+}
+
+// Levels - returns the current levels of the outputs and the modules
+func (c *levelController) Levels() types.Levels {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	result := types.Levels{
+		Outputs: make(map[string]string, len(c.outputs)),
+		Modules: make(map[string]string),
+	}
+	for k, v := range c.outputs {
+		result.Outputs[k] = strings.ToLower(zapToLogLevel(v.Level()).String())
+	}
+	for k, v := range c.moduleLevels() {
+		result.Modules[k] = strings.ToLower(v.String())
+	}
+	return result
+}
+
+// MARK: Private Methods
+
+// moduleLevels - returns a copy of the levels of the modules
+func (c *levelController) moduleLevels() map[string]types.LogLevel {
+	current, _ := c.modules.Load().(*moduleLevels)
+	result := make(map[string]types.LogLevel)
+	if current != nil {
+		for k, v := range current.levels {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// storeModuleLevels - replace the snapshot of the levels of the modules
+func (c *levelController) storeModuleLevels(levels map[string]types.LogLevel) {
+	verbose := types.ERROR - 1
+	for _, v := range levels {
+		if v > verbose {
+			verbose = v
+		}
+	}
+	c.modules.Store(&moduleLevels{levels: levels, verbose: verbose})
+}
+
+// moduleLevel - returns the level of the module, the longest parent module with a level is used for the sub-modules
+func (c *levelController) moduleLevel(module string) (types.LogLevel, bool) {
+	current, _ := c.modules.Load().(*moduleLevels)
+	if current == nil || len(current.levels) == 0 {
+		return 0, false
+	}
+
+	for name := module; ; {
+		if level, ok := current.levels[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexAny(name, "./")
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// outputLevel - returns the level of the output
+func (c *levelController) outputLevel(output string) (types.LogLevel, bool) {
+	c.lock.RLock()
+	atomicLevel, ok := c.outputs[output]
+	c.lock.RUnlock()
+
+	if !ok {
+		return 0, false
+	}
+	return zapToLogLevel(atomicLevel.Level()), true
+}
+
+// enabled - check whether the log of the module with the level is written to the output
+func (c *levelController) enabled(output string, level types.LogLevel, module string) bool {
+	if moduleLevel, ok := c.moduleLevel(module); ok {
+		return level <= moduleLevel
+	}
+	outputLevel, ok := c.outputLevel(output)
+	return ok && level <= outputLevel
+}
+
+// mayBeEnabled - check whether the log with the level can be written to the output by any module
+func (c *levelController) mayBeEnabled(output string, level types.LogLevel) bool {
+	if outputLevel, ok := c.outputLevel(output); ok && level <= outputLevel {
+		return true
+	}
+	current, _ := c.modules.Load().(*moduleLevels)
+	return current != nil && level <= current.verbose
+}
+
+// MARK: levelCore
+
+// levelCore - the core of an output that checks the levels of the controller, the module is read from the fields
+type levelCore struct {
+	zapcore.Core
+	output string
+	levels *levelController
+}
+
+// Enabled - check whether the level can be written by any module
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.levels.mayBeEnabled(c.output, zapToLogLevel(level))
+}
+
+// With - returns the core with the fields
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), output: c.output, levels: c.levels}
+}
+
+// Check - add the core to the entry if its level can be written
+func (c *levelCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+// Write - write the entry if the level of its module or the output allows it
+func (c *levelCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	module := ""
+	for _, f := range fields {
+		if f.Key == "module" && f.Type == zapcore.StringType {
+			module = f.String
+			break
+		}
+	}
+
+	if !c.levels.enabled(c.output, zapToLogLevel(entry.Level), module) {
+		return nil
+	}
+	return c.Core.Write(entry, fields)
+}
+
+// MARK: Private Functions
+
+// logLevelToZap - returns the zap level of the log level
+func logLevelToZap(level types.LogLevel) zapcore.Level {
+	switch level {
+	case types.ERROR:
+		return zapcore.ErrorLevel
+	case types.WARNING:
+		return zapcore.WarnLevel
+	case types.INFO:
+		return zapcore.InfoLevel
+	}
+	return zapcore.DebugLevel
+}
+
+// parseModuleLevels - returns the levels of the modules in the config, the invalid levels are ignored
+func parseModuleLevels(modules map[string]string) map[string]types.LogLevel {
+	result := make(map[string]types.LogLevel, len(modules))
+	for module, name := range modules {
+		if level, ok := types.ParseLogLevel(name); ok {
+			result[module] = level
+		}
+	}
+	return result
+}
@@ -0,0 +1,149 @@
+package logger
+
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_LevelControllerModules(t *testing.T) {
+	c := newLevelController(map[string]string{"db": "debug", "http": "warn", "bad": "verbose"})
+	c.register("console", types.INFO)
+
+	cases := []struct {
+		level  types.LogLevel
+		module string
+		want   bool
+	}{
+		{types.DEBUG, "db", true},
+		{types.DEBUG, "db.sql", true},
+		{types.DEBUG, "db/mongo", true},
+		{types.DEBUG, "dbx", false},
+		{types.INFO, "http.gin", false},
+		{types.WARNING, "http", true},
+		{types.DEBUG, "bad", false},
+		{types.INFO, "other", true},
+	}
+	for _, item := range cases {
+		if got := c.enabled("console", item.level, item.module); got != item.want {
+			t.Errorf("Enabled %v of module %v --> Expected: %v, but got %v", item.level, item.module, item.want, got)
+		}
+	}
+
+	if c.enabled("file", types.ERROR, "other") {
+		t.Errorf("Enabled of the inactive output --> Expected: %v, but got %v", false, true)
+	}
+	if err := c.SetOutputLevel("file", types.DEBUG); err == nil {
+		t.Errorf("Changing the level of the inactive output --> Expected an error, but got %v", err)
+	}
+
+	c.ClearModuleLevel("db")
+	if c.enabled("console", types.DEBUG, "db.sql") {
+		t.Errorf("Enabled after clearing the module --> Expected: %v, but got %v", false, true)
+	}
+
+	_ = c.SetOutputLevel("console", types.ERROR)
+	levels := c.Levels()
+	if levels.Outputs["console"] != "error" || levels.Modules["http"] != "warning" || len(levels.Modules) != 1 {
+		t.Errorf("Levels --> Expected: %v, but got %v", "console=error http=warning", levels)
+	}
+}
+
+func Test_ZapRuntimeLevels(t *testing.T) {
+	dir := t.TempDir()
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "levels"},
+		"logger": {
+			"type":    "zap",
+			"outputs": []interface{}{"file"},
+			"file":    map[string]interface{}{"level": "info", "path": dir},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	m := NewManager(source)
+	l, logErr := m.GetLogger()
+	if logErr != nil {
+		t.Fatalf("Getting logger --> Expected: %v, but got %v", nil, logErr)
+	}
+	logg := l.(*ZapWrapper)
+	defer logg.Close()
+
+	logg.Instance().Debug("hidden before", zap.String("module", "tester.sub"))
+
+	// the change of the config is applied without restart
+	err = source.Set("logger", "levels.tester", "debug")
+	if err != nil {
+		t.Fatalf("Changing the config --> Expected: %v, but got %v", nil, err)
+	}
+	logg.Instance().Debug("shown by module", zap.String("module", "tester.sub"))
+	logg.Instance().Debug("hidden other", zap.String("module", "other"))
+
+	// the admin changes are applied too
+	setErr := m.SetLevels(types.Levels{Outputs: map[string]string{"file": "debug"}, Modules: map[string]string{"tester": "default"}})
+	if setErr != nil {
+		t.Fatalf("Changing the levels --> Expected: %v, but got %v", nil, setErr)
+	}
+	logg.Instance().Debug("shown by output", zap.String("module", "other"))
+
+	setErr = m.SetLevels(types.Levels{Outputs: map[string]string{"file": "loud"}})
+	if setErr == nil {
+		t.Errorf("Changing to the invalid level --> Expected an error, but got %v", setErr)
+	}
+	levels, _ := m.Levels()
+	if levels.Outputs["file"] != "debug" || len(levels.Modules) != 0 {
+		t.Errorf("Levels --> Expected: %v, but got %v", "file=debug", levels)
+	}
+
+	logg.Sync()
+	data, err := os.ReadFile(filepath.Join(dir, "levels.log"))
+	if err != nil {
+		t.Fatalf("Reading the log file --> Expected: %v, but got %v", nil, err)
+	}
+	content := string(data)
+	for _, item := range []string{"shown by module", "shown by output"} {
+		if !strings.Contains(content, item) {
+			t.Errorf("Log file --> Expected to contain: %v, but got %v", item, content)
+		}
+	}
+	for _, item := range []string{"hidden before", "hidden other"} {
+		if strings.Contains(content, item) {
+			t.Errorf("Log file --> Expected not to contain: %v, but got %v", item, content)
+		}
+	}
+}
+
+func Test_ManagerOfLogger(t *testing.T) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base":   {"name": "levels"},
+		"logger": {"type": "logme", "outputs": []interface{}{"console"}, "console": map[string]interface{}{"level": "info"}},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	l, logErr := NewManager(source).GetLogger()
+	if logErr != nil {
+		t.Fatalf("Getting logger --> Expected: %v, but got %v", nil, logErr)
+	}
+	defer l.Close()
+
+	setErr := ManagerOf(l).SetLevels(types.Levels{Modules: map[string]string{"tester": "debug"}})
+	if setErr != nil {
+		t.Fatalf("Changing the levels --> Expected: %v, but got %v", nil, setErr)
+	}
+	levels := l.(types.LevelController).Levels()
+	if levels.Modules["tester"] != "debug" {
+		t.Errorf("Levels of the logger --> Expected: %v, but got %v", "tester=debug", levels)
+	}
+
+	if ManagerOf(nil) != GetManager() {
+		t.Errorf("Manager of the nil logger --> Expected: %v, but got %v", GetManager(), ManagerOf(nil))
+	}
+}
@@ -2,9 +2,11 @@ package logger
 
 // Imports needed list
 import (
+	"fmt"
 	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/logger/types"
 	"log"
+	"strings"
 	"sync"
 )
 
@@ -12,14 +14,16 @@ import (
 
 // Manager object
 type manager struct {
-	name   string
-	logger types.Logger
-	lock   sync.Mutex
+	name         string
+	configSource config.Provider
+	logger       types.Logger
+	lock         sync.Mutex
 }
 
 // MARK: Module variables
 var managerInstance *manager = nil
-var once sync.Once
+var fallbackManager *manager = nil
+var managerLock sync.Mutex
 
 // Module init function
 func init() {
@@ -32,23 +36,32 @@ func (m *manager) init() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	t, err := config.GetManager().Get(m.name, "type")
+	t, err := m.configSource.Get(m.name, "type")
 	if err != nil {
 		return
 	}
 
+	var l types.Logger
 	if t == "zap" {
-		m.logger = &ZapWrapper{}
-		m.logger.Constructor(m.name)
+		l = &ZapWrapper{configSource: m.configSource}
 	} else if t == "logme" {
-		m.logger = &LogMeWrapper{}
-		m.logger.Constructor(m.name)
+		l = &LogMeWrapper{configSource: m.configSource}
+	}
+
+	// the logger that is not constructed is not used, e.g. its channel is not created by the invalid config
+	if l != nil {
+		if err := l.Constructor(m.name); err != nil {
+			log.Printf("Cannot create the logger `%v`: %v", t, err)
+		} else {
+			m.logger = l
+		}
 	}
 
 	// Config config server to reload
-	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	wrapper, err := m.configSource.GetConfigWrapper(m.name)
 	if err == nil {
 		wrapper.RegisterChangeCallback(func() interface{} {
+			m.reloadLevels()
 			return nil
 		})
 	}
@@ -56,18 +69,85 @@ func (m *manager) init() {
 	return
 }
 
+// reloadLevels - apply the levels of the outputs and the modules of the changed config on the logger
+func (m *manager) reloadLevels() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	controller, ok := m.logger.(types.LevelController)
+	if !ok {
+		return
+	}
+
+	cfg, err := config.DecodeFrom[types.Config](m.configSource, m.name, "")
+	if err != nil {
+		log.Printf("Cannot reload the log levels: %v", err)
+		return
+	}
+
+	for _, output := range cfg.Outputs {
+		outputCfg, err := config.DecodeOptionalFrom[types.OutputConfig](m.configSource, m.name, output)
+		if err != nil {
+			continue
+		}
+		if level, ok := types.ParseLogLevel(outputCfg.Level); ok {
+			_ = controller.SetOutputLevel(output, level)
+		}
+	}
+	controller.SetModuleLevels(parseModuleLevels(cfg.Levels))
+}
+
 // MARK: Public Functions
 
+// NewManager - create an independent Logger Manager that reads its config from the source
+func NewManager(source config.Provider) *manager {
+	m := &manager{configSource: source}
+	m.init()
+	return m
+}
+
 // GetManager - This function returns singleton instance of Logger Manager
 func GetManager() *manager {
-	// once used for prevent race condition and manage critical section.
-	once.Do(func() {
-		managerInstance = &manager{}
-		managerInstance.init()
-	})
+	// lock used for prevent race condition and manage critical section.
+	managerLock.Lock()
+	defer managerLock.Unlock()
+
+	if managerInstance != nil {
+		return managerInstance
+	}
+
+	source := config.GetManager()
+	if source == nil {
+		// the config is not created yet, e.g. in the unit tests, so just a replaced logger can be used until it is
+		// created, then the manager of the config is created by the next call
+		if fallbackManager == nil {
+			fallbackManager = &manager{name: "logger"}
+		}
+		return fallbackManager
+	}
+	managerInstance = NewManager(source)
 	return managerInstance
 }
 
+// LoggerOrDefault - returns the logger, or the logger of the default manager if it's nil, so the managers that are
+// created by a config source log through the logger of that source when it's passed to them
+func LoggerOrDefault(l types.Logger) types.Logger {
+	if l != nil {
+		return l
+	}
+	l, _ = GetManager().GetLogger()
+	return l
+}
+
+// ManagerOf - returns the manager of the logger to read and change its levels, e.g. the logger that is passed to
+// the manager of a subsystem, the nil logger is the logger of the default manager
+func ManagerOf(l types.Logger) *manager {
+	if l == nil {
+		return GetManager()
+	}
+	return &manager{name: "logger", logger: l}
+}
+
 // GetLogger - This function returns logger instance
 func (m *manager) GetLogger() (types.Logger, *Error) {
 	m.lock.Lock()
@@ -78,3 +158,93 @@ func (m *manager) GetLogger() (types.Logger, *Error) {
 	}
 	return nil, NewError(nil)
 }
+
+// Levels - returns the current levels of the outputs and the modules of the logger
+func (m *manager) Levels() (types.Levels, *Error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	controller, ok := m.logger.(types.LevelController)
+	if !ok {
+		return types.Levels{}, NewError(fmt.Errorf("the logger does not support changing the levels"))
+	}
+	return controller.Levels(), nil
+}
+
+// SetLevels - change the levels of the outputs and the modules at runtime until the next reload of the config,
+// the empty level or `default` removes the level of the module
+func (m *manager) SetLevels(levels types.Levels) *Error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	controller, ok := m.logger.(types.LevelController)
+	if !ok {
+		return NewError(fmt.Errorf("the logger does not support changing the levels"))
+	}
+
+	// validate all levels first, so nothing is changed by an invalid request
+	outputs := make(map[string]types.LogLevel, len(levels.Outputs))
+	for output, name := range levels.Outputs {
+		level, ok := types.ParseLogLevel(name)
+		if !ok {
+			return NewError(fmt.Errorf("invalid log level `%v` of the output `%v`", name, output))
+		}
+		outputs[output] = level
+	}
+	current := controller.Levels()
+	for output := range outputs {
+		if _, ok := current.Outputs[output]; !ok {
+			return NewError(fmt.Errorf("log output `%v` is not active", output))
+		}
+	}
+	modules := make(map[string]types.LogLevel, len(levels.Modules))
+	for module, name := range levels.Modules {
+		if name == "" || strings.EqualFold(name, "default") {
+			continue
+		}
+		level, ok := types.ParseLogLevel(name)
+		if !ok {
+			return NewError(fmt.Errorf("invalid log level `%v` of the module `%v`", name, module))
+		}
+		modules[module] = level
+	}
+
+	for output, level := range outputs {
+		_ = controller.SetOutputLevel(output, level)
+	}
+	for module := range levels.Modules {
+		if level, ok := modules[module]; ok {
+			controller.SetModuleLevel(module, level)
+		} else {
+			controller.ClearModuleLevel(module)
+		}
+	}
+	return nil
+}
+
+// Stats - returns the counters of the log channel, e.g. the number of the dropped logs
+func (m *manager) Stats() (types.QueueStats, *Error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	reporter, ok := m.logger.(types.QueueReporter)
+	if !ok {
+		return types.QueueStats{}, NewError(fmt.Errorf("the logger does not report its channel"))
+	}
+	return reporter.Stats(), nil
+}
+
+// ReplaceLogger - use the logger instead of the configured one, e.g. the capture logger in a test.
+// It returns the function that restores the previous logger.
+func (m *manager) ReplaceLogger(l types.Logger) func() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	previous := m.logger
+	m.logger = l
+	return func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		m.logger = previous
+	}
+}
@@ -0,0 +1,184 @@
+package logger
+
+// Imports needed list
+import (
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MARK: logQueue
+
+// logQueue - the channel between the callers of `Log` and the goroutine that writes the logs to the outputs.
+// When the channel is full, the overflow policy decides whether the caller waits or a log is dropped.
+type logQueue struct {
+	ch         chan types.LogObject
+	policy     string
+	sampleRate uint64
+	sampleSeq  uint64
+	enqueued   uint64
+	dropped    uint64
+	pending    int64
+	closing    chan struct{}
+	done       chan struct{}
+	closed     bool
+	closeOnce  sync.Once
+	lock       sync.RWMutex
+	popLock    sync.Mutex
+}
+
+// newLogQueue - create the queue by the config of the logger
+func newLogQueue(cfg types.Config) *logQueue {
+	policy := cfg.OverflowPolicy
+	if policy == "" {
+		policy = types.OverflowBlock
+	}
+	sampleRate := cfg.SampleRate
+	if sampleRate < 1 {
+		sampleRate = 1
+	}
+
+	return &logQueue{
+		ch:         make(chan types.LogObject, cfg.ChannelSize),
+		policy:     policy,
+		sampleRate: uint64(sampleRate),
+		closing:    make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// start - run the goroutine that passes the queued logs to the handler in order
+func (q *logQueue) start(handler func(obj *types.LogObject)) {
+	go func() {
+		defer close(q.done)
+		for obj := range q.ch {
+			handler(&obj)
+			atomic.AddInt64(&q.pending, -1)
+		}
+	}()
+}
+
+// push - queue the log by the overflow policy, it returns false if the log is dropped
+func (q *logQueue) push(obj types.LogObject) bool {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+
+	if q.closed {
+		atomic.AddUint64(&q.dropped, 1)
+		return false
+	}
+
+	switch q.policy {
+	case types.OverflowBlock:
+		atomic.AddInt64(&q.pending, 1)
+		select {
+		case q.ch <- obj:
+			atomic.AddUint64(&q.enqueued, 1)
+			return true
+		case <-q.closing:
+			atomic.AddInt64(&q.pending, -1)
+			atomic.AddUint64(&q.dropped, 1)
+			return false
+		}
+	case types.OverflowDropOldest:
+		return q.pushDropOldest(obj)
+	case types.OverflowSample:
+		// the errors are kept while there is space, the rest are sampled once the channel is half full
+		if obj.Level != types.ERROR && len(q.ch) >= cap(q.ch)/2 {
+			if atomic.AddUint64(&q.sampleSeq, 1)%q.sampleRate != 0 {
+				atomic.AddUint64(&q.dropped, 1)
+				return false
+			}
+		}
+	}
+	return q.tryPush(obj)
+}
+
+// tryPush - queue the log if there is space, otherwise drop it
+func (q *logQueue) tryPush(obj types.LogObject) bool {
+	atomic.AddInt64(&q.pending, 1)
+	select {
+	case q.ch <- obj:
+		atomic.AddUint64(&q.enqueued, 1)
+		return true
+	default:
+		atomic.AddInt64(&q.pending, -1)
+		atomic.AddUint64(&q.dropped, 1)
+		return false
+	}
+}
+
+// pushDropOldest - queue the log, the oldest queued logs are removed until there is space
+func (q *logQueue) pushDropOldest(obj types.LogObject) bool {
+	q.popLock.Lock()
+	defer q.popLock.Unlock()
+
+	atomic.AddInt64(&q.pending, 1)
+	for {
+		select {
+		case q.ch <- obj:
+			atomic.AddUint64(&q.enqueued, 1)
+			return true
+		default:
+		}
+
+		select {
+		case <-q.ch:
+			atomic.AddInt64(&q.pending, -1)
+			atomic.AddUint64(&q.dropped, 1)
+		default:
+		}
+
+		// the unbuffered channel has no oldest log to drop
+		if cap(q.ch) == 0 {
+			atomic.AddInt64(&q.pending, -1)
+			atomic.AddUint64(&q.dropped, 1)
+			return false
+		}
+	}
+}
+
+// wait - wait until the queued logs are written or the timeout is passed, it returns false on timeout
+func (q *logQueue) wait(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for atomic.LoadInt64(&q.pending) > 0 {
+		if !time.Now().Before(deadline) {
+			return false
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return true
+}
+
+// close - stop accepting the logs and wait at most the timeout for the queued logs to be written.
+// It returns false if the queue is not drained in time, the remained logs are counted as dropped.
+func (q *logQueue) close(timeout time.Duration) bool {
+	drained := true
+	q.closeOnce.Do(func() {
+		// wake up the callers that wait for the space, then no one sends to the channel anymore
+		close(q.closing)
+		q.lock.Lock()
+		q.closed = true
+		close(q.ch)
+		q.lock.Unlock()
+
+		select {
+		case <-q.done:
+		case <-time.After(timeout):
+			drained = false
+			atomic.AddUint64(&q.dropped, uint64(len(q.ch)))
+		}
+	})
+	return drained
+}
+
+// stats - returns the counters of the queue
+func (q *logQueue) stats() types.QueueStats {
+	return types.QueueStats{
+		Capacity: cap(q.ch),
+		Queued:   len(q.ch),
+		Enqueued: atomic.LoadUint64(&q.enqueued),
+		Dropped:  atomic.LoadUint64(&q.dropped),
+	}
+}
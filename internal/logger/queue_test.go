@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockedQueue - returns the started queue whose handler waits until the returned function is called
+func blockedQueue(policy string, size int) (*logQueue, *[]string, func()) {
+	q := newLogQueue(types.Config{ChannelSize: size, OverflowPolicy: policy, SampleRate: 2})
+
+	var lock sync.Mutex
+	var written []string
+	release := make(chan struct{})
+	q.start(func(obj *types.LogObject) {
+		<-release
+		lock.Lock()
+		written = append(written, obj.Message.(string))
+		lock.Unlock()
+	})
+
+	var once sync.Once
+	return q, &written, func() { once.Do(func() { close(release) }) }
+}
+
+func logObject(level types.LogLevel, message string) types.LogObject {
+	return *types.NewLogObject(level, "tester", types.AppType, time.Now(), message, nil)
+}
+
+func Test_LogQueueDropNewest(t *testing.T) {
+	q, written, release := blockedQueue(types.OverflowDropNewest, 2)
+
+	// the first log is taken by the blocked handler, two logs fill the channel
+	for _, item := range []string{"1", "2", "3", "4", "5"} {
+		q.push(logObject(types.INFO, item))
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	stats := q.stats()
+	if stats.Dropped != 2 || stats.Enqueued != 3 || stats.Queued != 2 || stats.Capacity != 2 {
+		t.Errorf("Queue stats --> Expected: %v, but got %v", "dropped 2, enqueued 3, queued 2", stats)
+	}
+
+	release()
+	if !q.close(time.Second) {
+		t.Errorf("Draining the queue --> Expected: %v, but got %v", true, false)
+	}
+	if len(*written) != 3 || (*written)[2] != "3" {
+		t.Errorf("Written logs --> Expected: %v, but got %v", []string{"1", "2", "3"}, *written)
+	}
+}
+
+func Test_LogQueueDropOldest(t *testing.T) {
+	q, written, release := blockedQueue(types.OverflowDropOldest, 2)
+
+	for _, item := range []string{"1", "2", "3", "4", "5"} {
+		q.push(logObject(types.INFO, item))
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	release()
+	q.close(time.Second)
+	if len(*written) != 3 || (*written)[1] != "4" || (*written)[2] != "5" {
+		t.Errorf("Written logs --> Expected: %v, but got %v", []string{"1", "4", "5"}, *written)
+	}
+	if q.stats().Dropped != 2 {
+		t.Errorf("Dropped logs --> Expected: %v, but got %v", 2, q.stats().Dropped)
+	}
+}
+
+func Test_LogQueueSample(t *testing.T) {
+	q, _, release := blockedQueue(types.OverflowSample, 4)
+	defer q.close(time.Second)
+	defer release()
+
+	q.push(logObject(types.INFO, "taken"))
+	time.Sleep(5 * time.Millisecond)
+	q.push(logObject(types.INFO, "1"))
+	q.push(logObject(types.INFO, "2"))
+
+	// the channel is half full, so 1 of every 2 logs is kept but the errors
+	q.push(logObject(types.INFO, "sampled"))
+	q.push(logObject(types.INFO, "kept"))
+	q.push(logObject(types.ERROR, "error"))
+
+	stats := q.stats()
+	if stats.Queued != 4 || stats.Dropped != 1 {
+		t.Errorf("Queue stats --> Expected: %v, but got %v", "queued 4, dropped 1", stats)
+	}
+}
+
+func Test_LogQueueBlockAndDrainTimeout(t *testing.T) {
+	q, _, release := blockedQueue(types.OverflowBlock, 1)
+	defer release()
+
+	q.push(logObject(types.INFO, "taken"))
+	time.Sleep(5 * time.Millisecond)
+	q.push(logObject(types.INFO, "queued"))
+
+	pushed := make(chan bool, 1)
+	go func() {
+		pushed <- q.push(logObject(types.INFO, "waiting"))
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatalf("Pushing to the full queue with the block policy --> Expected to wait, but it returned")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// closing wakes up the waiting caller and gives up draining after the timeout
+	start := time.Now()
+	if q.close(50 * time.Millisecond) {
+		t.Errorf("Draining the blocked queue --> Expected: %v, but got %v", false, true)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("Closing the queue --> Expected to return after the timeout, but took %v", time.Since(start))
+	}
+	if <-pushed {
+		t.Errorf("Pushing while closing --> Expected: %v, but got %v", false, true)
+	}
+	if q.push(logObject(types.INFO, "closed")) {
+		t.Errorf("Pushing to the closed queue --> Expected: %v, but got %v", false, true)
+	}
+}
+
+func Test_LogQueueDefaultPolicy(t *testing.T) {
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base":   {"name": "queue"},
+		"logger": {"type": "logme", "outputs": []interface{}{}},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	cfg, err := config.DecodeFrom[types.Config](source, "logger", "")
+	if err != nil || cfg.OverflowPolicy != types.OverflowBlock {
+		t.Errorf("Default overflow policy --> Expected: %v, but got %v (%v)", types.OverflowBlock, cfg.OverflowPolicy, err)
+	}
+
+	if q := newLogQueue(types.Config{ChannelSize: 1}); q.policy != types.OverflowBlock {
+		t.Errorf("Overflow policy of the queue --> Expected: %v, but got %v", types.OverflowBlock, q.policy)
+	}
+}
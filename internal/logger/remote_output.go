@@ -0,0 +1,372 @@
+package logger
+
+// Imports needed list
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"go.uber.org/zap/zapcore"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MARK: Constants
+
+const (
+	// remoteDialTimeout - the time that connecting to the log server can take
+	remoteDialTimeout = 5 * time.Second
+	// remoteWriteTimeout - the time that writing one message to the log server can take
+	remoteWriteTimeout = 5 * time.Second
+	// remoteMinBackoff - the first wait after a failed connection, it is doubled on every failure
+	remoteMinBackoff = 100 * time.Millisecond
+	// remoteMaxBackoff - the longest wait between the connection attempts
+	remoteMaxBackoff = 30 * time.Second
+	// defaultRemoteQueueSize - the queue size of the remote output if the `channel_size` is zero
+	defaultRemoteQueueSize = 1000
+)
+
+// MARK: remoteRecord
+
+// remoteRecord - one log that is encoded by the protocol of the remote output
+type remoteRecord struct {
+	Time    time.Time
+	Level   types.LogLevel
+	Message string
+	Fields  map[string]interface{}
+}
+
+// remoteEncoder - encode the record to the packets that are written to the connection in order
+type remoteEncoder interface {
+	encode(r remoteRecord) ([][]byte, error)
+}
+
+// MARK: remoteOutput
+
+// remoteOutput - send the logs to a log server in the background.
+// The records are queued without blocking; while the server is unreachable, they are dropped and counted,
+// and the connection is retried with exponential backoff.
+type remoteOutput struct {
+	name      string
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	encoder   remoteEncoder
+	queue     chan [][]byte
+	done      chan struct{}
+	conn      net.Conn
+	backoff   time.Duration
+	nextDial  time.Time
+	dropped   uint64
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// newRemoteOutput - create the remote output and start sending its queue
+func newRemoteOutput(name string, network string, address string, tlsConfig *tls.Config, encoder remoteEncoder, queueSize int) *remoteOutput {
+	if queueSize <= 0 {
+		queueSize = defaultRemoteQueueSize
+	}
+
+	o := &remoteOutput{
+		name:      name,
+		network:   network,
+		address:   address,
+		tlsConfig: tlsConfig,
+		encoder:   encoder,
+		queue:     make(chan [][]byte, queueSize),
+		done:      make(chan struct{}),
+	}
+
+	o.wg.Add(1)
+	go o.run()
+	return o
+}
+
+// send - encode the record and queue it, the record is dropped if the queue is full
+func (o *remoteOutput) send(r remoteRecord) {
+	packets, err := o.encoder.encode(r)
+	if err != nil {
+		atomic.AddUint64(&o.dropped, 1)
+		return
+	}
+
+	select {
+	case <-o.done:
+		atomic.AddUint64(&o.dropped, 1)
+	case o.queue <- packets:
+	default:
+		atomic.AddUint64(&o.dropped, 1)
+	}
+}
+
+// run - the goroutine that writes the queued packets to the server
+func (o *remoteOutput) run() {
+	defer o.wg.Done()
+
+	for {
+		select {
+		case packets := <-o.queue:
+			o.write(packets)
+		case <-o.done:
+			// send what is queued already, then close the connection
+			for {
+				select {
+				case packets := <-o.queue:
+					o.write(packets)
+				default:
+					if o.conn != nil {
+						_ = o.conn.Close()
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// write - write the packets to the connection, it reconnects once if the connection is broken
+func (o *remoteOutput) write(packets [][]byte) {
+	for attempt := 0; attempt < 2; attempt++ {
+		if o.conn == nil && !o.dial() {
+			break
+		}
+
+		err := o.writePackets(packets)
+		if err == nil {
+			return
+		}
+
+		log.Printf("Cannot write the logs to the `%s` output: %v", o.name, err)
+		_ = o.conn.Close()
+		o.conn = nil
+	}
+	atomic.AddUint64(&o.dropped, 1)
+}
+
+// writePackets - write all packets of one record to the connection
+func (o *remoteOutput) writePackets(packets [][]byte) error {
+	_ = o.conn.SetWriteDeadline(time.Now().Add(remoteWriteTimeout))
+	for _, packet := range packets {
+		_, err := o.conn.Write(packet)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dial - connect to the server if the backoff time is passed, it returns whether the connection is ready
+func (o *remoteOutput) dial() bool {
+	if time.Now().Before(o.nextDial) {
+		return false
+	}
+
+	dialer := &net.Dialer{Timeout: remoteDialTimeout}
+	var conn net.Conn
+	var err error
+	if o.tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", o.address, o.tlsConfig)
+	} else {
+		conn, err = dialer.Dial(o.network, o.address)
+	}
+
+	if err != nil {
+		if o.backoff == 0 {
+			o.backoff = remoteMinBackoff
+		} else if o.backoff < remoteMaxBackoff {
+			o.backoff *= 2
+			if o.backoff > remoteMaxBackoff {
+				o.backoff = remoteMaxBackoff
+			}
+		}
+		o.nextDial = time.Now().Add(o.backoff)
+		log.Printf("Cannot connect to the `%s` output: %v, retry in %v", o.name, err, o.backoff)
+		return false
+	}
+
+	o.conn = conn
+	o.backoff = 0
+	o.nextDial = time.Time{}
+	return true
+}
+
+// Dropped - returns the number of the records that could not be sent
+func (o *remoteOutput) Dropped() uint64 {
+	return atomic.LoadUint64(&o.dropped)
+}
+
+// Close - send the queued records and close the connection
+func (o *remoteOutput) Close() {
+	o.closeOnce.Do(func() {
+		close(o.done)
+		o.wg.Wait()
+	})
+}
+
+// MARK: remoteCore
+
+// remoteCore - the zap core that sends the entries to the remote output
+type remoteCore struct {
+	zapcore.LevelEnabler
+	output *remoteOutput
+	fields []zapcore.Field
+}
+
+// With - returns a core with the fields added
+func (c *remoteCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &remoteCore{LevelEnabler: c.LevelEnabler, output: c.output}
+	clone.fields = append(append(clone.fields, c.fields...), fields...)
+	return clone
+}
+
+// Check - add the core to the checked entry if its level is enabled
+func (c *remoteCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+// Write - convert the entry to the record and send it
+func (c *remoteCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	c.output.send(remoteRecord{
+		Time:    entry.Time,
+		Level:   zapToLogLevel(entry.Level),
+		Message: entry.Message,
+		Fields:  enc.Fields,
+	})
+	return nil
+}
+
+// Sync - the records are sent in the background, so there is nothing to flush
+func (c *remoteCore) Sync() error {
+	return nil
+}
+
+// MARK: Private Functions
+
+// zapToLogLevel - convert the level of zap to the log level
+func zapToLogLevel(level zapcore.Level) types.LogLevel {
+	switch {
+	case level <= zapcore.DebugLevel:
+		return types.DEBUG
+	case level == zapcore.InfoLevel:
+		return types.INFO
+	case level == zapcore.WarnLevel:
+		return types.WARNING
+	}
+	return types.ERROR
+}
+
+// syslogSeverity - returns the syslog severity of the log level, it is used by both GELF and syslog
+func syslogSeverity(level types.LogLevel) int {
+	switch level {
+	case types.ERROR:
+		return 3
+	case types.WARNING:
+		return 4
+	case types.INFO:
+		return 6
+	}
+	return 7
+}
+
+// hostName - returns the name of the host that is sent with the logs
+func hostName() string {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		return "-"
+	}
+	return name
+}
+
+// logObjectRecord - convert the log object to the record of the remote outputs
+func logObjectRecord(serviceName string, obj *types.LogObject) remoteRecord {
+	fields := types.FieldsMap(obj.Fields)
+	fields["service"] = serviceName
+	fields["module"] = obj.Module
+	fields["log_type"] = obj.LogType
+	if obj.Additional != nil {
+		fields["additional"] = fmt.Sprintf("%v", obj.Additional)
+	}
+
+	return remoteRecord{
+		Time:    time.Unix(0, obj.Time),
+		Level:   obj.Level,
+		Message: fmt.Sprintf("%v", obj.Message),
+		Fields:  fields,
+	}
+}
+
+// openRemoteOutput - create the `graylog` or `syslog` output from its config, it returns the output and its level
+func openRemoteOutput(source config.Provider, category string, name string, appName string, queueSize int) (*remoteOutput, string, error) {
+	switch name {
+	case "graylog":
+		cfg, err := config.DecodeFrom[types.GraylogConfig](source, category, name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		encoder := &gelfEncoder{
+			host:        hostName(),
+			protocol:    cfg.Protocol,
+			compression: cfg.Compression,
+			chunkSize:   cfg.ChunkSize,
+		}
+		if cfg.Stdout {
+			encoder.stdout = os.Stdout
+		}
+		address := net.JoinHostPort(cfg.IP, fmt.Sprintf("%d", cfg.Port))
+		return newRemoteOutput(name, cfg.Protocol, address, nil, encoder, queueSize), cfg.Level, nil
+	case "syslog":
+		cfg, err := config.DecodeFrom[types.SyslogConfig](source, category, name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if cfg.AppName != "" {
+			appName = cfg.AppName
+		}
+		encoder := &syslogEncoder{
+			facility: cfg.Facility,
+			host:     hostName(),
+			appName:  appName,
+			framing:  cfg.CType != "udp",
+		}
+
+		var tlsConfig *tls.Config
+		network := cfg.CType
+		if cfg.CType == "tls" {
+			network = "tcp"
+			tlsConfig = &tls.Config{ServerName: cfg.IP, InsecureSkipVerify: cfg.InsecureSkipVerify}
+			if cfg.CAFile != "" {
+				data, err := os.ReadFile(cfg.CAFile)
+				if err != nil {
+					return nil, "", err
+				}
+				pool := x509.NewCertPool()
+				if !pool.AppendCertsFromPEM(data) {
+					return nil, "", fmt.Errorf("no certificate is found in the ca file: %s", cfg.CAFile)
+				}
+				tlsConfig.RootCAs = pool
+			}
+		}
+		address := net.JoinHostPort(cfg.IP, fmt.Sprintf("%d", cfg.Port))
+		return newRemoteOutput(name, network, address, tlsConfig, encoder, queueSize), cfg.Level, nil
+	}
+	return nil, "", fmt.Errorf("the remote output `%s` is not supported", name)
+}
@@ -0,0 +1,228 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func listenerPort(t *testing.T, addr net.Addr) int {
+	_, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		t.Fatalf("Listener address --> Expected: %v, but got %v", nil, err)
+	}
+	p, _ := strconv.Atoi(port)
+	return p
+}
+
+func Test_GelfUdpChunkedOutput(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listening on udp --> Expected: %v, but got %v", nil, err)
+	}
+	defer conn.Close()
+
+	encoder := &gelfEncoder{host: "tester", protocol: "udp", compression: "gzip", chunkSize: 100}
+	output := newRemoteOutput("graylog", "udp", conn.LocalAddr().String(), nil, encoder, 10)
+	defer output.Close()
+
+	message := strings.Repeat("a long message that is not compressed well 0123456789 ", 2) + time.Now().String()
+	output.send(remoteRecord{
+		Time:    time.Now(),
+		Level:   types.WARNING,
+		Message: message,
+		Fields:  map[string]interface{}{"module": "tester", "id": "x", "n": int64(3)},
+	})
+
+	// the chunks may arrive in any order, they are ordered by their sequence number
+	var chunks [][]byte
+	buf := make([]byte, 2048)
+	_ = conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	for received := 0; chunks == nil || received < len(chunks); received++ {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("Reading the gelf chunk --> Expected: %v, but got %v", nil, err)
+		}
+		if buf[0] != 0x1e || buf[1] != 0x0f {
+			t.Fatalf("Gelf chunk magic bytes --> Expected: %v, but got %v", []byte{0x1e, 0x0f}, buf[:2])
+		}
+		if n > 100 {
+			t.Errorf("Gelf chunk size --> Expected at most: %v, but got %v", 100, n)
+		}
+		if chunks == nil {
+			chunks = make([][]byte, buf[11])
+		}
+		chunks[buf[10]] = append([]byte{}, buf[12:n]...)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(bytes.Join(chunks, nil)))
+	if err != nil {
+		t.Fatalf("Decompressing the gelf message --> Expected: %v, but got %v", nil, err)
+	}
+	data, _ := io.ReadAll(reader)
+
+	var got map[string]interface{}
+	err = json.Unmarshal(data, &got)
+	if err != nil {
+		t.Fatalf("Decoding the gelf message --> Expected: %v, but got %v", nil, err)
+	}
+	if got["short_message"] != message || got["version"] != "1.1" || got["host"] != "tester" {
+		t.Errorf("Gelf message --> Expected: %v, but got %v", message, got)
+	}
+	if got["level"] != float64(4) {
+		t.Errorf("Gelf level --> Expected: %v, but got %v", 4, got["level"])
+	}
+	if got["_module"] != "tester" || got["_id_"] != "x" || got["_n"] != float64(3) {
+		t.Errorf("Gelf additional fields --> Expected: %v, but got %v", "tester x 3", got)
+	}
+}
+
+func Test_ZapGraylogTcpOutput(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listening on tcp --> Expected: %v, but got %v", nil, err)
+	}
+	defer listener.Close()
+
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "gelf"},
+		"logger": {
+			"type":    "zap",
+			"outputs": []interface{}{"graylog"},
+			"graylog": map[string]interface{}{"level": "info", "ip": "127.0.0.1", "port": listenerPort(t, listener.Addr()), "protocol": "tcp"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	logg := &ZapWrapper{configSource: source}
+	err = logg.Constructor("logger")
+	if err != nil {
+		t.Fatalf("Creating logger --> Expected: %v, but got %v", nil, err)
+	}
+	defer logg.Close()
+
+	logg.Log(types.NewLogObject(types.DEBUG, "tester", types.AppType, time.Now(), "filtered", nil))
+	logg.Log(types.NewLogObject(types.ERROR, "tester", types.AppType, time.Now(), "failed", nil).WithFields(types.String("user", "u1")))
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("Accepting the connection --> Expected: %v, but got %v", nil, err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+
+	data, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil {
+		t.Fatalf("Reading the gelf message --> Expected: %v, but got %v", nil, err)
+	}
+
+	var got map[string]interface{}
+	err = json.Unmarshal(data[:len(data)-1], &got)
+	if err != nil {
+		t.Fatalf("Decoding the gelf message --> Expected: %v, but got %v", nil, err)
+	}
+	if got["short_message"] != "failed" || got["level"] != float64(3) {
+		t.Errorf("Gelf message --> Expected: %v, but got %v", "failed", got)
+	}
+	if got["_user"] != "u1" || got["_service"] != "gelf" || got["_module"] != "tester" {
+		t.Errorf("Gelf additional fields --> Expected: %v, but got %v", "u1 gelf tester", got)
+	}
+}
+
+func Test_LogMeSyslogTcpOutput(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listening on tcp --> Expected: %v, but got %v", nil, err)
+	}
+	defer listener.Close()
+
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "syslog"},
+		"logger": {
+			"type":    "logme",
+			"outputs": []interface{}{"syslog"},
+			"syslog":  map[string]interface{}{"ip": "127.0.0.1", "port": listenerPort(t, listener.Addr()), "ctype": "tcp", "facility": 16},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	logg := &LogMeWrapper{configSource: source}
+	err = logg.Constructor("logger")
+	if err != nil {
+		t.Fatalf("Creating logger --> Expected: %v, but got %v", nil, err)
+	}
+
+	logg.Log(types.NewLogObject(types.INFO, "tester", types.AppType, time.Now(), "hello", nil).WithFields(types.String("quote", `a "b" ]`)))
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("Accepting the connection --> Expected: %v, but got %v", nil, err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+
+	// octet counting: the length of the message, a space and the message
+	reader := bufio.NewReader(conn)
+	length, err := reader.ReadString(' ')
+	if err != nil {
+		t.Fatalf("Reading the syslog frame --> Expected: %v, but got %v", nil, err)
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(length))
+	data := make([]byte, n)
+	_, err = io.ReadFull(reader, data)
+	if err != nil {
+		t.Fatalf("Reading the syslog message --> Expected: %v, but got %v", nil, err)
+	}
+
+	line := string(data)
+	// facility 16 (local0) * 8 + severity 6 (info)
+	if !strings.HasPrefix(line, "<134>1 ") {
+		t.Errorf("Syslog priority and version --> Expected: %v, but got %v", "<134>1 ", line)
+	}
+	if !strings.Contains(line, " syslog ") || !strings.Contains(line, " APP [zhycan@32473 ") {
+		t.Errorf("Syslog header --> Expected the app name and the msgid, but got %v", line)
+	}
+	if !strings.Contains(line, `module="tester"`) || !strings.Contains(line, `quote="a \"b\" \]"`) {
+		t.Errorf("Syslog structured data --> Expected the escaped fields, but got %v", line)
+	}
+	if !strings.HasSuffix(line, "] hello") {
+		t.Errorf("Syslog message --> Expected: %v, but got %v", "hello", line)
+	}
+}
+
+func Test_RemoteOutputUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listening on tcp --> Expected: %v, but got %v", nil, err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	output := newRemoteOutput("syslog", "tcp", address, nil, &syslogEncoder{framing: true}, 2)
+
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		output.send(remoteRecord{Time: time.Now(), Level: types.INFO, Message: "lost"})
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("Sending to the unreachable server --> Expected not to block, but took %v", time.Since(start))
+	}
+
+	output.Close()
+	if output.Dropped() != 100 {
+		t.Errorf("Dropped records --> Expected: %v, but got %v", 100, output.Dropped())
+	}
+}
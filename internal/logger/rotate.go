@@ -0,0 +1,359 @@
+package logger
+
+// Imports needed list
+import (
+	"compress/gzip"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// MARK: Constants
+
+const (
+	// backupTimeFormat - the time in the name of the rotated files, e.g. `zhycan-2023-01-02T15-04-05.000.log`
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	// compressSuffix - the suffix of the gzipped rotated files
+	compressSuffix = ".gz"
+)
+
+// MARK: Variables
+
+var (
+	sighupLock  sync.Mutex
+	sighupFiles = make(map[*rotatingFile]struct{})
+	sighupCh    chan os.Signal
+)
+
+// MARK: rotatingFile
+
+// rotatingFile - the writer of the `file` output that rotates the file by its size or by the interval,
+// compresses the rotated files and removes the old ones in the background
+type rotatingFile struct {
+	filename     string
+	maxSize      int64
+	interval     time.Duration
+	maxBackups   int
+	maxAge       time.Duration
+	compress     bool
+	now          func() time.Time
+	file         *os.File
+	size         int64
+	nextRotation time.Time
+	millCh       chan struct{}
+	millOnce     sync.Once
+	closed       bool
+	lock         sync.Mutex
+}
+
+// newRotatingFile - create the writer of the file by the config, the file is opened on the first write
+func newRotatingFile(filename string, cfg types.FileConfig) *rotatingFile {
+	f := &rotatingFile{
+		filename:   filename,
+		maxSize:    int64(cfg.MaxSize) * 1024 * 1024,
+		interval:   cfg.Interval,
+		maxBackups: cfg.MaxBackups,
+		maxAge:     time.Duration(cfg.MaxAge) * 24 * time.Hour,
+		compress:   cfg.Compress,
+		now:        time.Now,
+	}
+	if cfg.ReopenOnSighup {
+		reopenOnSighup(f)
+	}
+	return f
+}
+
+// Write - write the data to the file, the file is rotated before if the data exceeds the size or the interval is passed.
+// It returns os.ErrClosed after Close, so the file is not opened again.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	if f.file == nil {
+		err := f.open()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if f.shouldRotate(int64(len(p))) {
+		err := f.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Sync - flush the file to the disk
+func (f *rotatingFile) Sync() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
+
+// Reopen - close and open the file again, so the file that is moved by an external logrotate is released
+func (f *rotatingFile) Reopen() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+	if f.file != nil {
+		_ = f.file.Close()
+		f.file = nil
+	}
+	return f.open()
+}
+
+// Close - close the file and stop reopening it on SIGHUP
+func (f *rotatingFile) Close() error {
+	sighupLock.Lock()
+	delete(sighupFiles, f)
+	sighupLock.Unlock()
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.closed = true
+	if f.millCh != nil {
+		close(f.millCh)
+		f.millCh = nil
+	}
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// MARK: Private Methods
+
+// open - open the file for appending, the directories are created if not exist
+func (f *rotatingFile) open() error {
+	err := os.MkdirAll(filepath.Dir(f.filename), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	if f.interval > 0 {
+		f.nextRotation = f.now().Truncate(f.interval).Add(f.interval)
+	}
+	return nil
+}
+
+// shouldRotate - check whether the size of the file exceeds the limit with the new data or the interval is passed
+func (f *rotatingFile) shouldRotate(n int64) bool {
+	if f.maxSize > 0 && f.size > 0 && f.size+n > f.maxSize {
+		return true
+	}
+	return f.interval > 0 && !f.now().Before(f.nextRotation)
+}
+
+// rotate - move the file to the backup name, open a new file and clean the backups in the background
+func (f *rotatingFile) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(f.filename, f.backupName(f.now()))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	err = f.open()
+	if err != nil {
+		return err
+	}
+
+	f.millOnce.Do(func() {
+		f.millCh = make(chan struct{}, 1)
+		go f.millRunner(f.millCh)
+	})
+	select {
+	case f.millCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// backupName - returns the name of the rotated file at the time
+func (f *rotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.filename)
+	prefix := strings.TrimSuffix(f.filename, ext)
+	return prefix + "-" + t.UTC().Format(backupTimeFormat) + ext
+}
+
+// backups - returns the rotated files from the newest to the oldest with their rotation time
+func (f *rotatingFile) backups() ([]string, []time.Time, error) {
+	dir := filepath.Dir(f.filename)
+	ext := filepath.Ext(f.filename)
+	prefix := strings.TrimSuffix(filepath.Base(f.filename), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	type backup struct {
+		name string
+		time time.Time
+	}
+	var items []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		stamp := strings.TrimPrefix(name, prefix)
+		stamp = strings.TrimSuffix(stamp, compressSuffix)
+		if !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		t, err := time.Parse(backupTimeFormat, strings.TrimSuffix(stamp, ext))
+		if err != nil {
+			continue
+		}
+		items = append(items, backup{name: filepath.Join(dir, name), time: t})
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].time.After(items[j].time) })
+	names := make([]string, len(items))
+	times := make([]time.Time, len(items))
+	for i, item := range items {
+		names[i] = item.name
+		times[i] = item.time
+	}
+	return names, times, nil
+}
+
+// millRunner - the goroutine that compresses and removes the backups after every rotation
+func (f *rotatingFile) millRunner(ch chan struct{}) {
+	for range ch {
+		err := f.mill()
+		if err != nil {
+			log.Printf("Cannot clean the rotated log files of %s: %v", f.filename, err)
+		}
+	}
+}
+
+// mill - remove the backups more than the limit or older than the max age, then compress the rest
+func (f *rotatingFile) mill() error {
+	names, times, err := f.backups()
+	if err != nil {
+		return err
+	}
+
+	for i, name := range names {
+		expired := f.maxAge > 0 && f.now().Sub(times[i]) > f.maxAge
+		if (f.maxBackups > 0 && i >= f.maxBackups) || expired {
+			_ = os.Remove(name)
+			continue
+		}
+
+		if f.compress && !strings.HasSuffix(name, compressSuffix) {
+			err := compressFile(name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MARK: Private Functions
+
+// compressFile - gzip the file to `<name>.gz` and remove it
+func compressFile(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(name+compressSuffix, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(name + compressSuffix)
+		return err
+	}
+	return os.Remove(name)
+}
+
+// reopenOnSighup - reopen the file whenever the process gets SIGHUP, e.g. after the external logrotate moved it
+func reopenOnSighup(f *rotatingFile) {
+	sighupLock.Lock()
+	defer sighupLock.Unlock()
+
+	sighupFiles[f] = struct{}{}
+	if sighupCh != nil {
+		return
+	}
+
+	sighupCh = make(chan os.Signal, 1)
+	signal.Notify(sighupCh, syscall.SIGHUP)
+	go func() {
+		for range sighupCh {
+			sighupLock.Lock()
+			files := make([]*rotatingFile, 0, len(sighupFiles))
+			for item := range sighupFiles {
+				files = append(files, item)
+			}
+			sighupLock.Unlock()
+
+			for _, item := range files {
+				err := item.Reopen()
+				if err != nil {
+					log.Printf("Cannot reopen the log file %s: %v", item.filename, err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"compress/gzip"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func waitForFiles(dir string, pattern string, count int) []string {
+	var files []string
+	for i := 0; i < 100; i++ {
+		files, _ = filepath.Glob(filepath.Join(dir, pattern))
+		if len(files) == count {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return files
+}
+
+func Test_RotatingFileBySize(t *testing.T) {
+	dir := t.TempDir()
+	f := newRotatingFile(filepath.Join(dir, "app.log"), types.FileConfig{MaxBackups: 2, Compress: true})
+	f.maxSize = 10
+	defer f.Close()
+
+	// every rotation needs a distinct time in the backup name
+	current := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	f.now = func() time.Time {
+		current = current.Add(time.Second)
+		return current
+	}
+
+	for i := 0; i < 4; i++ {
+		_, err := f.Write([]byte("0123456789"))
+		if err != nil {
+			t.Fatalf("Writing to the file --> Expected: %v, but got %v", nil, err)
+		}
+	}
+
+	backups := waitForFiles(dir, "app-*.log.gz", 2)
+	if len(backups) != 2 {
+		t.Fatalf("Compressed backups --> Expected: %v, but got %v", 2, backups)
+	}
+	if plain, _ := filepath.Glob(filepath.Join(dir, "app-*.log")); len(plain) != 0 {
+		t.Errorf("Uncompressed backups --> Expected: %v, but got %v", 0, plain)
+	}
+
+	file, _ := os.Open(backups[0])
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("Reading the compressed backup --> Expected: %v, but got %v", nil, err)
+	}
+	data, _ := io.ReadAll(reader)
+	_ = file.Close()
+	if string(data) != "0123456789" {
+		t.Errorf("Content of the backup --> Expected: %v, but got %v", "0123456789", string(data))
+	}
+
+	data, _ = os.ReadFile(filepath.Join(dir, "app.log"))
+	if string(data) != "0123456789" {
+		t.Errorf("Content of the current file --> Expected: %v, but got %v", "0123456789", string(data))
+	}
+}
+
+func Test_RotatingFileByInterval(t *testing.T) {
+	dir := t.TempDir()
+	f := newRotatingFile(filepath.Join(dir, "app.log"), types.FileConfig{Interval: time.Hour, MaxAge: 1})
+	defer f.Close()
+
+	current := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	f.now = func() time.Time { return current }
+
+	// an old backup that is expired by the max age
+	old := filepath.Join(dir, "app-"+current.Add(-48*time.Hour).Format(backupTimeFormat)+".log")
+	_ = os.WriteFile(old, []byte("old"), 0644)
+
+	_, _ = f.Write([]byte("first"))
+	current = current.Add(30 * time.Minute)
+	_, _ = f.Write([]byte("second"))
+	if backups, _ := filepath.Glob(filepath.Join(dir, "app-2023-01-02*.log")); len(backups) != 0 {
+		t.Errorf("Backups before the interval --> Expected: %v, but got %v", 0, backups)
+	}
+
+	current = current.Add(30 * time.Minute)
+	_, _ = f.Write([]byte("third"))
+
+	backups := waitForFiles(dir, "app-*.log", 1)
+	if len(backups) != 1 || !strings.Contains(backups[0], "2023-01-02T04-04-05") {
+		t.Fatalf("Backups after the interval --> Expected: %v, but got %v", "app-2023-01-02T04-04-05.000.log", backups)
+	}
+	data, _ := os.ReadFile(backups[0])
+	if string(data) != "firstsecond" {
+		t.Errorf("Content of the backup --> Expected: %v, but got %v", "firstsecond", string(data))
+	}
+}
+
+func Test_RotatingFileReopenOnSighup(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	f := newRotatingFile(name, types.FileConfig{ReopenOnSighup: true})
+	defer f.Close()
+
+	_, _ = f.Write([]byte("before"))
+
+	// the external logrotate moves the file and sends SIGHUP
+	err := os.Rename(name, name+".1")
+	if err != nil {
+		t.Fatalf("Moving the file --> Expected: %v, but got %v", nil, err)
+	}
+	process, _ := os.FindProcess(os.Getpid())
+	err = process.Signal(syscall.SIGHUP)
+	if err != nil {
+		t.Fatalf("Sending SIGHUP --> Expected: %v, but got %v", nil, err)
+	}
+
+	if files := waitForFiles(dir, "app.log", 1); len(files) != 1 {
+		t.Fatalf("Reopened file --> Expected: %v, but got %v", name, files)
+	}
+	_, _ = f.Write([]byte("after"))
+
+	data, _ := os.ReadFile(name)
+	if string(data) != "after" {
+		t.Errorf("Content of the reopened file --> Expected: %v, but got %v", "after", string(data))
+	}
+	data, _ = os.ReadFile(name + ".1")
+	if string(data) != "before" {
+		t.Errorf("Content of the moved file --> Expected: %v, but got %v", "before", string(data))
+	}
+}
+
+func Test_RotatingFileWriteAfterClose(t *testing.T) {
+	dir := t.TempDir()
+	f := newRotatingFile(filepath.Join(dir, "app.log"), types.FileConfig{})
+
+	_, err := f.Write([]byte("before\n"))
+	if err != nil {
+		t.Fatalf("Writing to the file --> Expected: %v, but got %v", nil, err)
+	}
+	_ = f.Close()
+
+	_, err = f.Write([]byte("after\n"))
+	if err != os.ErrClosed {
+		t.Errorf("Writing after closing --> Expected: %v, but got %v", os.ErrClosed, err)
+	}
+	if f.file != nil {
+		t.Errorf("File after writing to the closed writer --> Expected: %v, but got %v", nil, f.file)
+	}
+	if err := f.Reopen(); err != os.ErrClosed {
+		t.Errorf("Reopening after closing --> Expected: %v, but got %v", os.ErrClosed, err)
+	}
+}
@@ -0,0 +1,159 @@
+package logger
+
+// Imports needed list
+import (
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MARK: Constants
+
+const (
+	// sampleKeyLength - the longest part of the message that is used to find the similar logs
+	sampleKeyLength = 256
+	// defaultSampleInterval - the interval of the sampling if it is zero in the config
+	defaultSampleInterval = time.Second
+)
+
+// MARK: logSampler
+
+// sampleCounter - the number of the similar logs in the current interval
+type sampleCounter struct {
+	level      types.LogLevel
+	module     string
+	message    string
+	count      uint64
+	suppressed uint64
+}
+
+// logSampler - write the first `initial` similar logs in every interval and then 1 of every `thereafter`,
+// the summary of the suppressed logs is emitted at the end of the interval
+type logSampler struct {
+	initial    uint64
+	thereafter uint64
+	interval   time.Duration
+	emit       func(obj *types.LogObject)
+	counters   map[string]*sampleCounter
+	suppressed uint64
+	stopCh     chan struct{}
+	done       chan struct{}
+	stopOnce   sync.Once
+	lock       sync.Mutex
+}
+
+// newLogSampler - create the sampler by the config, the summaries are passed to the emit function
+func newLogSampler(cfg types.SamplingConfig, emit func(obj *types.LogObject)) *logSampler {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultSampleInterval
+	}
+
+	return &logSampler{
+		initial:    uint64(cfg.Initial),
+		thereafter: uint64(cfg.Thereafter),
+		interval:   interval,
+		emit:       emit,
+		counters:   make(map[string]*sampleCounter),
+		stopCh:     make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// start - run the goroutine that starts a new interval and emits the summaries
+func (s *logSampler) start() {
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.tick()
+			case <-s.stopCh:
+				s.tick()
+				return
+			}
+		}
+	}()
+}
+
+// stop - stop the sampler and emit the summaries of the current interval
+func (s *logSampler) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		<-s.done
+	})
+}
+
+// allow - check whether the log is written or it is suppressed
+func (s *logSampler) allow(obj *types.LogObject) bool {
+	message := sampleMessage(obj.Message)
+	key := obj.Module + "\x00" + message
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok {
+		c = &sampleCounter{level: obj.Level, module: obj.Module, message: message}
+		s.counters[key] = c
+	}
+
+	c.count++
+	if c.count <= s.initial || (s.thereafter > 0 && (c.count-s.initial)%s.thereafter == 0) {
+		return true
+	}
+	c.suppressed++
+	atomic.AddUint64(&s.suppressed, 1)
+	return false
+}
+
+// Suppressed - returns the number of all suppressed logs
+func (s *logSampler) Suppressed() uint64 {
+	return atomic.LoadUint64(&s.suppressed)
+}
+
+// MARK: Private Methods
+
+// tick - start a new interval and emit one summary for every similar logs that some of them are suppressed
+func (s *logSampler) tick() {
+	s.lock.Lock()
+	counters := s.counters
+	s.counters = make(map[string]*sampleCounter)
+	s.lock.Unlock()
+
+	for _, c := range counters {
+		if c.suppressed == 0 {
+			continue
+		}
+
+		obj := types.NewLogObject(
+			c.level, c.module, types.SamplingType, time.Now().UTC(),
+			fmt.Sprintf("%d similar logs are suppressed in the last %v: %v", c.suppressed, s.interval, c.message), nil,
+		).WithFields(types.Int64("suppressed", int64(c.suppressed)), types.Int64("total", int64(c.count)))
+		s.emit(obj)
+	}
+}
+
+// MARK: Private Functions
+
+// sampleMessage - returns the first line of the message that the similar logs share, e.g. the sql and the elapsed
+// time of the db logs are in the next lines
+func sampleMessage(message interface{}) string {
+	text, ok := message.(string)
+	if !ok {
+		text = fmt.Sprintf("%v", message)
+	}
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+	if len(text) > sampleKeyLength {
+		text = text[:sampleKeyLength]
+	}
+	return text
+}
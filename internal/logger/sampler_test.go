@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_LogSamplerAllow(t *testing.T) {
+	var summaries []*types.LogObject
+	s := newLogSampler(types.SamplingConfig{Initial: 2, Thereafter: 3, Interval: time.Hour}, func(obj *types.LogObject) {
+		summaries = append(summaries, obj)
+	})
+	s.start()
+
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		// the lines after the first one are not part of the key, e.g. the elapsed time of the queries
+		msg := "db.go:10 connection refused\n[" + time.Duration(i).String() + "] select 1"
+		if s.allow(types.NewLogObject(types.ERROR, "db", types.AppType, time.Now(), msg, nil)) {
+			allowed++
+		}
+	}
+	if allowed != 4 {
+		t.Errorf("Allowed logs --> Expected: %v, but got %v", 4, allowed)
+	}
+	if !s.allow(types.NewLogObject(types.ERROR, "http", types.AppType, time.Now(), "db.go:10 connection refused", nil)) {
+		t.Errorf("Allowed log of the other module --> Expected: %v, but got %v", true, false)
+	}
+
+	s.stop()
+	if s.Suppressed() != 6 {
+		t.Errorf("Suppressed logs --> Expected: %v, but got %v", 6, s.Suppressed())
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("Summaries --> Expected: %v, but got %v", 1, len(summaries))
+	}
+	summary := summaries[0]
+	if summary.Module != "db" || summary.Level != types.ERROR || summary.LogType != types.SamplingType.String() {
+		t.Errorf("Summary --> Expected: %v, but got %v", "db ERROR LOG_SAMPLING", summary)
+	}
+	if !strings.HasPrefix(summary.Message.(string), "6 similar logs are suppressed") {
+		t.Errorf("Summary message --> Expected: %v, but got %v", "6 similar logs are suppressed", summary.Message)
+	}
+}
+
+func Test_LogMeSampling(t *testing.T) {
+	dir := t.TempDir()
+	source, err := config.FromMap(map[string]map[string]interface{}{
+		"base": {"name": "sampling"},
+		"logger": {
+			"type":     "logme",
+			"outputs":  []interface{}{"file"},
+			"file":     map[string]interface{}{"level": "debug", "path": dir},
+			"sampling": map[string]interface{}{"enabled": true, "initial": 3, "thereafter": 0, "interval": "1h"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+	}
+
+	logg := &LogMeWrapper{configSource: source}
+	err = logg.Constructor("logger")
+	if err != nil {
+		t.Fatalf("Creating logger --> Expected: %v, but got %v", nil, err)
+	}
+
+	for i := 0; i < 20; i++ {
+		logg.Log(types.NewLogObject(types.ERROR, "tester", types.AppType, time.Now(), "storm", nil))
+	}
+	if logg.Stats().Sampled != 17 {
+		t.Errorf("Sampled logs --> Expected: %v, but got %v", 17, logg.Stats().Sampled)
+	}
+	logg.Close()
+
+	data, err := os.ReadFile(filepath.Join(dir, "sampling.log"))
+	if err != nil {
+		t.Fatalf("Reading the log file --> Expected: %v, but got %v", nil, err)
+	}
+	content := string(data)
+	if strings.Count(content, "- storm ...") != 3 {
+		t.Errorf("Written logs --> Expected: %v, but got %v", 3, content)
+	}
+	if !strings.Contains(content, "17 similar logs are suppressed in the last 1h0m0s: storm") {
+		t.Errorf("Summary line --> Expected the suppressed count, but got %v", content)
+	}
+}
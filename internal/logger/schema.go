@@ -0,0 +1,19 @@
+package logger
+
+// Imports needed list
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+)
+
+// Schema - returns the JSON Schema of the `logger` config module,
+// `file`, `db`, `graylog` and `syslog` have their own configs and every other key is the config of an output
+func Schema() *config.Schema {
+	s := config.SchemaOf(types.Config{})
+	s.AdditionalProperties = config.SchemaOf(types.OutputConfig{})
+	s.Properties["file"] = config.SchemaOf(types.FileConfig{})
+	s.Properties["db"] = config.SchemaOf(types.DbOutputConfig{})
+	s.Properties["graylog"] = config.SchemaOf(types.GraylogConfig{})
+	s.Properties["syslog"] = config.SchemaOf(types.SyslogConfig{})
+	return config.ModuleSchema("logger", s)
+}
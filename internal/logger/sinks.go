@@ -0,0 +1,192 @@
+package logger
+
+// Imports needed list
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// MARK: Variables
+
+var (
+	sinkLock      sync.RWMutex
+	sinkFactories = make(map[string]types.SinkFactory)
+	// builtinOutputs - the names of the outputs that the custom outputs cannot take
+	builtinOutputs = []string{"console", "file", "db", "graylog", "syslog"}
+)
+
+// MARK: Public Functions
+
+// RegisterSink - register the factory of the custom output, the output is created when its name is in `outputs`
+func RegisterSink(name string, factory types.SinkFactory) *Error {
+	name = strings.TrimSpace(name)
+	if name == "" || factory == nil {
+		return NewError(fmt.Errorf("the name and the factory of the log output are required"))
+	}
+	for _, item := range builtinOutputs {
+		if item == name {
+			return NewError(fmt.Errorf("log output `%v` is a builtin output", name))
+		}
+	}
+
+	sinkLock.Lock()
+	defer sinkLock.Unlock()
+
+	if _, ok := sinkFactories[name]; ok {
+		return NewError(fmt.Errorf("log output `%v` is already registered", name))
+	}
+	sinkFactories[name] = factory
+	return nil
+}
+
+// UnregisterSink - remove the factory of the custom output, the created outputs are not affected
+func UnregisterSink(name string) {
+	sinkLock.Lock()
+	defer sinkLock.Unlock()
+
+	delete(sinkFactories, name)
+}
+
+// MARK: sinkOutput
+
+// sinkOutput - the custom output that is created by the registered factory
+type sinkOutput struct {
+	name        string
+	serviceName string
+	encoder     string
+	sink        types.Sink
+	errors      uint64
+}
+
+// openSinkOutput - create the custom output by its registered factory and its config block,
+// it returns false if no factory is registered with the name
+func openSinkOutput(source config.Provider, category string, name string, serviceName string) (*sinkOutput, types.LogLevel, bool, error) {
+	sinkLock.RLock()
+	factory, ok := sinkFactories[name]
+	sinkLock.RUnlock()
+	if !ok {
+		return nil, types.DEBUG, false, nil
+	}
+
+	cfg, err := config.DecodeOptionalFrom[types.SinkConfig](source, category, name)
+	if err != nil {
+		return nil, types.DEBUG, true, err
+	}
+	level, ok := types.ParseLogLevel(cfg.Level)
+	if !ok {
+		return nil, types.DEBUG, true, fmt.Errorf("invalid log level `%v`", cfg.Level)
+	}
+
+	sink, err := factory(types.SinkOptions{
+		Name:        name,
+		ServiceName: serviceName,
+		Config:      cfg,
+		Decode: func(out interface{}) error {
+			return config.UnmarshalOptional(source, category, name, out)
+		},
+	})
+	if err != nil {
+		return nil, types.DEBUG, true, err
+	}
+	if sink == nil {
+		return nil, types.DEBUG, true, fmt.Errorf("the factory returns no output")
+	}
+
+	return &sinkOutput{name: name, serviceName: serviceName, encoder: cfg.Encoder, sink: sink}, level, true, nil
+}
+
+// write - encode the log and pass it to the sink, its errors and panics never reach the other outputs
+func (s *sinkOutput) write(c *types.LogObject) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.failed(fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	// the sink gets its own copy, so it cannot change the log of the other outputs
+	obj := *c
+	obj.Fields = append([]types.Field{}, c.Fields...)
+
+	data, err := encodeSinkLog(s.encoder, s.serviceName, &obj)
+	if err == nil {
+		err = s.sink.Write(&obj, data)
+	}
+	if err != nil {
+		s.failed(err)
+	}
+}
+
+// Errors - returns the number of the failed writes
+func (s *sinkOutput) Errors() uint64 {
+	return atomic.LoadUint64(&s.errors)
+}
+
+// Close - close the sink, its panic is recovered too
+func (s *sinkOutput) Close() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Cannot close the log output `%v`: panic: %v", s.name, r)
+		}
+	}()
+
+	err := s.sink.Close()
+	if err != nil {
+		log.Printf("Cannot close the log output `%v`: %v", s.name, err)
+	}
+}
+
+// MARK: Private Methods
+
+// failed - count the error, just the first one and then every 100th are printed to not flood the stdout
+func (s *sinkOutput) failed(err error) {
+	n := atomic.AddUint64(&s.errors, 1)
+	if n == 1 || n%100 == 0 {
+		log.Printf("Cannot write to the log output `%v` (%v errors): %v", s.name, n, err)
+	}
+}
+
+// MARK: Private Functions
+
+// encodeSinkLog - encode the log by the encoder of the custom output
+func encodeSinkLog(encoder string, serviceName string, obj *types.LogObject) ([]byte, error) {
+	if encoder == "text" {
+		return []byte(fmt.Sprintf(
+			"%v %v >>> %7v >>> (%v/%v)  - %v ... %v%v\n",
+			serviceName,
+			obj.Time,
+			obj.Level.String(),
+			obj.LogType,
+			obj.Module,
+			obj.Message,
+			obj.Additional,
+			fieldsSuffix(obj.Fields),
+		)), nil
+	}
+
+	record := map[string]interface{}{
+		"service":    serviceName,
+		"level":      obj.Level.String(),
+		"module":     obj.Module,
+		"log_type":   obj.LogType,
+		"time":       obj.Time,
+		"message":    fmt.Sprintf("%v", obj.Message),
+		"additional": obj.Additional,
+	}
+	if len(obj.Fields) > 0 {
+		record["fields"] = types.FieldsMap(obj.Fields)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		// the additional value may not be encodable, e.g. a channel
+		record["additional"] = fmt.Sprintf("%v", obj.Additional)
+		data, err = json.Marshal(record)
+	}
+	return data, err
+}
@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ringSink - the custom output of the tests that keeps the encoded logs
+type ringSink struct {
+	prefix string
+	lines  []string
+	closed bool
+	lock   sync.Mutex
+}
+
+func (s *ringSink) Write(obj *types.LogObject, encoded []byte) error {
+	if obj.Message == "panic" {
+		panic("broken sink")
+	}
+	if obj.Message == "fail" {
+		return errors.New("broken sink")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lines = append(s.lines, s.prefix+string(encoded))
+	return nil
+}
+
+func (s *ringSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closed = true
+	return nil
+}
+
+func Test_RegisterSink(t *testing.T) {
+	factory := func(options types.SinkOptions) (types.Sink, error) { return &ringSink{}, nil }
+
+	if err := RegisterSink("console", factory); err == nil {
+		t.Errorf("Registering the builtin output --> Expected an error, but got %v", err)
+	}
+	if err := RegisterSink("", factory); err == nil {
+		t.Errorf("Registering without the name --> Expected an error, but got %v", err)
+	}
+	if err := RegisterSink("test_register", factory); err != nil {
+		t.Errorf("Registering the output --> Expected: %v, but got %v", nil, err)
+	}
+	defer UnregisterSink("test_register")
+	if err := RegisterSink("test_register", factory); err == nil {
+		t.Errorf("Registering the output twice --> Expected an error, but got %v", err)
+	}
+}
+
+func Test_CustomSinkOutputs(t *testing.T) {
+	var created []*ringSink
+	err := RegisterSink("test_ring", func(options types.SinkOptions) (types.Sink, error) {
+		var cfg struct {
+			Prefix string `json:"prefix"`
+		}
+		if err := options.Decode(&cfg); err != nil {
+			return nil, err
+		}
+		sink := &ringSink{prefix: cfg.Prefix}
+		created = append(created, sink)
+		return sink, nil
+	})
+	if err != nil {
+		t.Fatalf("Registering the output --> Expected: %v, but got %v", nil, err)
+	}
+	defer UnregisterSink("test_ring")
+
+	for _, loggerType := range []string{"zap", "logme"} {
+		source, err := config.FromMap(map[string]map[string]interface{}{
+			"base": {"name": "sinks"},
+			"logger": {
+				"type":      loggerType,
+				"outputs":   []interface{}{"test_ring"},
+				"test_ring": map[string]interface{}{"level": "info", "prefix": "> "},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Creating config manager --> Expected: %v, but got %v", nil, err)
+		}
+
+		var logg types.Logger = &ZapWrapper{configSource: source}
+		if loggerType == "logme" {
+			logg = &LogMeWrapper{configSource: source}
+		}
+		err = logg.Constructor("logger")
+		if err != nil {
+			t.Fatalf("Creating %v logger --> Expected: %v, but got %v", loggerType, nil, err)
+		}
+
+		// the broken writes never stop the next logs
+		for _, msg := range []string{"hidden", "panic", "fail", "written"} {
+			level := types.INFO
+			if msg == "hidden" {
+				level = types.DEBUG
+			}
+			logg.Log(types.NewLogObject(level, "tester", types.AppType, time.Now(), msg, nil).WithFields(types.String("user", "u1")))
+		}
+		logg.Close()
+
+		sink := created[len(created)-1]
+		if !sink.closed || len(sink.lines) != 1 || sink.lines[0][:2] != "> " {
+			t.Fatalf("Written logs of %v --> Expected: %v, but got %v", loggerType, "one closed line", sink.lines)
+		}
+
+		var got map[string]interface{}
+		err = json.Unmarshal([]byte(sink.lines[0][2:]), &got)
+		if err != nil {
+			t.Fatalf("Decoding the log of %v --> Expected: %v, but got %v", loggerType, nil, err)
+		}
+		fields, _ := got["fields"].(map[string]interface{})
+		if got["message"] != "written" || got["service"] != "sinks" || got["level"] != "INFO" || fields["user"] != "u1" {
+			t.Errorf("Encoded log of %v --> Expected: %v, but got %v", loggerType, "written", got)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package logger
+
+// Imports needed list
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// MARK: Constants
+
+const (
+	// syslogSDID - the id of the structured data element that carries the fields, 32473 is the example enterprise number
+	syslogSDID = "zhycan@32473"
+	// syslogTimeFormat - the timestamp of RFC5424 allows at most 6 digits of the fractions of a second
+	syslogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// MARK: syslogEncoder
+
+// syslogEncoder - encode the records in RFC5424, the fields are written as the structured data
+type syslogEncoder struct {
+	facility int
+	host     string
+	appName  string
+	framing  bool
+}
+
+// encode - returns the message as one packet, it is prefixed by its length if it is sent over a stream
+func (e *syslogEncoder) encode(r remoteRecord) ([][]byte, error) {
+	msgID := "-"
+	if v, ok := r.Fields["log_type"].(string); ok && v != "" {
+		msgID = syslogHeaderValue(v, 32)
+	}
+
+	line := fmt.Sprintf("<%d>1 %s %s %s %d %s %s %s",
+		e.facility*8+syslogSeverity(r.Level),
+		r.Time.UTC().Format(syslogTimeFormat),
+		syslogHeaderValue(e.host, 255),
+		syslogHeaderValue(e.appName, 48),
+		os.Getpid(),
+		msgID,
+		syslogStructuredData(r.Fields),
+		r.Message,
+	)
+
+	if e.framing {
+		line = fmt.Sprintf("%d %s", len(line), line)
+	}
+	return [][]byte{[]byte(line)}, nil
+}
+
+// MARK: Private Functions
+
+// syslogHeaderValue - the header fields are printable ascii without spaces and are limited in length, the empty value is `-`
+func syslogHeaderValue(value string, limit int) string {
+	result := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, value)
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	if result == "" {
+		return "-"
+	}
+	return result
+}
+
+// syslogParamName - the names of the params are printable ascii without `=`, ` `, `]` and `"`, at most 32 characters
+func syslogParamName(name string) string {
+	result := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+
+	if len(result) > 32 {
+		result = result[:32]
+	}
+	return result
+}
+
+// syslogParamValue - escape `"`, `\` and `]` in the value of the param
+func syslogParamValue(value interface{}) string {
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	case nil:
+		text = ""
+	case error:
+		text = v.Error()
+	case fmt.Stringer:
+		text = v.String()
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			text = fmt.Sprintf("%v", v)
+		} else {
+			text = string(data)
+		}
+	default:
+		text = fmt.Sprintf("%v", v)
+	}
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(text)
+}
+
+// syslogStructuredData - returns the fields as one structured data element sorted by their names
+func syslogStructuredData(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return "-"
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString("[" + syslogSDID)
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf(` %s="%s"`, syslogParamName(k), syslogParamValue(fields[k])))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
@@ -0,0 +1,156 @@
+package types
+
+// Imports needed list
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// MARK: Constants
+
+// Keys of the fields that are carried by the context
+const (
+	RequestIDKey = "request_id"
+	TraceIDKey   = "trace_id"
+	SpanIDKey    = "span_id"
+	UserKey      = "user"
+)
+
+// Headers and metadata keys that the entry points read the ids from
+const (
+	RequestIDHeader   = "X-Request-ID"
+	TraceParentHeader = "traceparent"
+)
+
+// MARK: Variables
+
+// contextFieldsKey - the key of the fields in the context
+type contextFieldsKey struct{}
+
+// MARK: Public Functions
+
+// ContextWithFields - returns a copy of the context that carries the fields too, the later field with the same key wins
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	current := FieldsFromContext(ctx)
+	result := make([]Field, 0, len(current)+len(fields))
+	for _, f := range current {
+		replaced := false
+		for _, item := range fields {
+			if item.Key == f.Key {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, f)
+		}
+	}
+	result = append(result, fields...)
+	return context.WithValue(ctx, contextFieldsKey{}, result)
+}
+
+// FieldsFromContext - returns the fields that the context carries
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(contextFieldsKey{}).([]Field)
+	return fields
+}
+
+// WithRequestID - returns a copy of the context that carries the request id
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return ContextWithFields(ctx, String(RequestIDKey, requestID))
+}
+
+// WithTrace - returns a copy of the context that carries the trace id and the span id
+func WithTrace(ctx context.Context, traceID string, spanID string) context.Context {
+	return ContextWithFields(ctx, String(TraceIDKey, traceID), String(SpanIDKey, spanID))
+}
+
+// WithUser - returns a copy of the context that carries the user
+func WithUser(ctx context.Context, user string) context.Context {
+	return ContextWithFields(ctx, String(UserKey, user))
+}
+
+// ContextValue - returns the string value of the field that the context carries, e.g. the request id
+func ContextValue(ctx context.Context, key string) string {
+	for _, f := range FieldsFromContext(ctx) {
+		if f.Key == key {
+			if v, ok := f.Value.(string); ok {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// NewRequestID - returns a random id for the requests that have no id
+func NewRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// ParseTraceParent - returns the trace id and the span id of the W3C `traceparent` header,
+// e.g. `00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01`
+func ParseTraceParent(value string) (string, string, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", "", false
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil || strings.Trim(parts[1], "0") == "" {
+		return "", "", false
+	}
+	if _, err := hex.DecodeString(parts[2]); err != nil || strings.Trim(parts[2], "0") == "" {
+		return "", "", false
+	}
+	return strings.ToLower(parts[1]), strings.ToLower(parts[2]), true
+}
+
+// ContextFromRequest - returns a copy of the context that carries the request id and the trace of the incoming request.
+// The request id is generated if the request has not any; it returns the request id too, so it can be sent back.
+func ContextFromRequest(ctx context.Context, requestID string, traceParent string) (context.Context, string) {
+	if requestID == "" {
+		requestID = NewRequestID()
+	}
+
+	fields := []Field{String(RequestIDKey, requestID)}
+	if traceID, spanID, ok := ParseTraceParent(traceParent); ok {
+		fields = append(fields, String(TraceIDKey, traceID), String(SpanIDKey, spanID))
+	}
+	return ContextWithFields(ctx, fields...), requestID
+}
+
+// MARK: Public Methods
+
+// WithContext - append the fields that the context carries to the log object and return it
+func (o *LogObject) WithContext(ctx context.Context) *LogObject {
+	fields := FieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return o
+	}
+
+	// the fields of the log itself win over the fields of the context
+	result := make([]Field, 0, len(fields)+len(o.Fields))
+	for _, f := range fields {
+		exist := false
+		for _, item := range o.Fields {
+			if item.Key == f.Key {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			result = append(result, f)
+		}
+	}
+	o.Fields = append(result, o.Fields...)
+	return o
+}
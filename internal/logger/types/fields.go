@@ -0,0 +1,83 @@
+package types
+
+// Imports needed list
+import (
+	"time"
+)
+
+// MARK: Field
+
+// Field - a typed key/value pair of the log that is written as a separate field by every output
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String - create a field with the string value
+func String(key string, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int - create a field with the int value
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: int64(value)}
+}
+
+// Int64 - create a field with the int64 value
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Float64 - create a field with the float64 value
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool - create a field with the boolean value
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration - create a field with the duration value
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Time - create a field with the time value
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err - create the `error` field, a nil error is kept as nil
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+// Any - create a field with any value, the outputs encode it by its type
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// MARK: Public Methods
+
+// Plain - returns the value in the form that can be encoded to json, e.g. the error is converted to its message
+func (f Field) Plain() interface{} {
+	switch v := f.Value.(type) {
+	case error:
+		return v.Error()
+	case time.Duration:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return f.Value
+}
+
+// FieldsMap - returns the fields as a map, the later field with the same key wins
+func FieldsMap(fields []Field) map[string]interface{} {
+	result := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		result[f.Key] = f.Plain()
+	}
+	return result
+}
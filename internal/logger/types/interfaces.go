@@ -8,3 +8,28 @@ type Logger interface {
 	IsInitialized() bool
 	Sync()
 }
+
+// LevelController - the logger that its levels can be changed at runtime
+type LevelController interface {
+	SetOutputLevel(output string, level LogLevel) error
+	SetModuleLevel(module string, level LogLevel)
+	ClearModuleLevel(module string)
+	SetModuleLevels(levels map[string]LogLevel)
+	Levels() Levels
+}
+
+// QueueReporter - the logger that reports the counters of its channel
+type QueueReporter interface {
+	Stats() QueueStats
+}
+
+// Sink - the custom output of the logger, e.g. kafka or a webhook, that is registered by the application.
+// It is called from the goroutine of the logger with the log and its encoded form; the errors and panics are
+// counted and never stop the other outputs.
+type Sink interface {
+	Write(obj *LogObject, encoded []byte) error
+	Close() error
+}
+
+// SinkFactory - create the custom output by its options
+type SinkFactory func(options SinkOptions) (Sink, error)
@@ -12,6 +12,134 @@ const (
 	MAX = 6
 )
 
+// Config - the structure of the `logger` config module.
+// The `levels` are the levels of the modules, e.g. `{"db": "debug"}`, that replace the levels of the outputs
+// for the logs of the module and its sub-modules (`db.mongo`, `db/sql`, ...).
+//
+// The `overflow_policy` is applied when the channel is full: `block` (the default) waits for the space, `drop_newest`
+// drops the new log, `drop_oldest` drops the oldest queued log, and `sample` keeps 1 of every `sample_rate` logs once
+// the channel is half full (the errors are always kept while there is space). `Close` waits at most `drain_timeout` for the queued logs.
+type Config struct {
+	Type           string            `json:"type" validate:"oneof=zap logme"`
+	Outputs        []string          `json:"outputs" validate:"required"`
+	ChannelSize    int               `json:"channel_size" default:"1000" validate:"min=0"`
+	OverflowPolicy string            `json:"overflow_policy" default:"block" validate:"oneof=block drop_newest drop_oldest sample"`
+	SampleRate     int               `json:"sample_rate" default:"10" validate:"min=1"`
+	DrainTimeout   time.Duration     `json:"drain_timeout" default:"5s" validate:"min=0"`
+	Options        []string          `json:"options"`
+	Levels         map[string]string `json:"levels"`
+	Sampling       SamplingConfig    `json:"sampling"`
+}
+
+// SamplingConfig - the sampling of the similar logs, the logs with the same module and the same first line of
+// the message are similar. In every `interval`, the first `initial` similar logs are written and then 1 of every
+// `thereafter` (none if it is zero); the number of the suppressed logs is written at the end of the interval.
+type SamplingConfig struct {
+	Enabled    bool          `json:"enabled"`
+	Initial    int           `json:"initial" default:"100" validate:"min=0"`
+	Thereafter int           `json:"thereafter" default:"100" validate:"min=0"`
+	Interval   time.Duration `json:"interval" default:"1s" validate:"min=0"`
+}
+
+// Overflow policies of the log channel
+const (
+	OverflowBlock      = "block"
+	OverflowDropNewest = "drop_newest"
+	OverflowDropOldest = "drop_oldest"
+	OverflowSample     = "sample"
+)
+
+// QueueStats - the counters of the log channel
+type QueueStats struct {
+	Capacity int    `json:"capacity"`
+	Queued   int    `json:"queued"`
+	Enqueued uint64 `json:"enqueued"`
+	Dropped  uint64 `json:"dropped"`
+	Sampled  uint64 `json:"sampled"`
+}
+
+// Levels - the current levels of the outputs and the modules of the logger
+type Levels struct {
+	Outputs map[string]string `json:"outputs"`
+	Modules map[string]string `json:"modules"`
+}
+
+// OutputConfig - the common config of every output in the `logger` config module
+type OutputConfig struct {
+	Level string `json:"level" default:"debug"`
+	Path  string `json:"path" default:"logs"`
+}
+
+// FileConfig - the config of the `file` output, the file is rotated by its size (`max_size` in megabytes) or by the interval.
+// The rotated files are kept as `<name>-<time>.log` (gzipped if `compress` is set) and removed after
+// `max_backups` files or `max_age` days; zero keeps them all.
+type FileConfig struct {
+	Level          string        `json:"level" default:"debug"`
+	Path           string        `json:"path" default:"logs"`
+	MaxSize        int           `json:"max_size" validate:"min=0"`
+	Interval       time.Duration `json:"interval" validate:"min=0"`
+	MaxBackups     int           `json:"max_backups" validate:"min=0"`
+	MaxAge         int           `json:"max_age" validate:"min=0"`
+	Compress       bool          `json:"compress"`
+	ReopenOnSighup bool          `json:"reopen_on_sighup"`
+}
+
+// DbOutputConfig - the config of the `db` output, the logs are inserted in batches of `batch_size` rows or every
+// `flush_interval` into the `use` connection of the `db` module. The `sql` connections use the `zhycan_logs` table and
+// the `mongo` connections use the `collection`; the logs older than `retention` are purged every `purge_interval`,
+// zero keeps them all.
+type DbOutputConfig struct {
+	Level         string        `json:"level" default:"debug"`
+	Use           string        `json:"use" validate:"required"`
+	Type          string        `json:"type" default:"sql" validate:"oneof=sql mongo"`
+	Collection    string        `json:"collection" default:"zhycan_logs"`
+	BatchSize     int           `json:"batch_size" default:"100" validate:"min=1"`
+	FlushInterval time.Duration `json:"flush_interval" default:"1s" validate:"min=0"`
+	Retention     time.Duration `json:"retention" validate:"min=0"`
+	PurgeInterval time.Duration `json:"purge_interval" default:"1h" validate:"min=0"`
+}
+
+// GraylogConfig - the config of the `graylog` output, the logs are sent in GELF 1.1.
+// The messages over udp are compressed and chunked; over tcp they are null-terminated.
+type GraylogConfig struct {
+	Level       string `json:"level" default:"debug"`
+	IP          string `json:"ip" validate:"required"`
+	Port        int    `json:"port" default:"12201" validate:"min=1,max=65535"`
+	Protocol    string `json:"protocol" default:"udp" validate:"oneof=udp tcp"`
+	Compression string `json:"compression" default:"gzip" validate:"oneof=gzip zlib none"`
+	ChunkSize   int    `json:"chunk_size" default:"1420" validate:"min=64"`
+	Stdout      bool   `json:"stdout"`
+}
+
+// SyslogConfig - the config of the `syslog` output, the logs are sent in RFC5424.
+// The messages over tcp and tls are framed by octet counting (RFC6587).
+type SyslogConfig struct {
+	Level              string `json:"level" default:"debug"`
+	IP                 string `json:"ip" validate:"required"`
+	Port               int    `json:"port" default:"514" validate:"min=1,max=65535"`
+	CType              string `json:"ctype" default:"udp" validate:"oneof=udp tcp tls"`
+	Facility           int    `json:"facility" default:"1" validate:"min=0,max=23"`
+	AppName            string `json:"app_name"`
+	CAFile             string `json:"ca_file"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+}
+
+// SinkConfig - the common config of the custom outputs, the rest of the block is read by the sink itself.
+// The `json` encoder writes one JSON object per log and the `text` encoder writes the line of the `file` output.
+type SinkConfig struct {
+	Level   string `json:"level" default:"debug"`
+	Encoder string `json:"encoder" default:"json" validate:"oneof=json text"`
+}
+
+// SinkOptions - the options that the custom output is created by
+type SinkOptions struct {
+	Name        string
+	ServiceName string
+	Config      SinkConfig
+	// Decode - decode the config block of the output into the object
+	Decode func(out interface{}) error
+}
+
 // LogObject - all methods that want to log must transfer object of this.
 type LogObject struct {
 	Level      LogLevel
@@ -20,6 +148,7 @@ type LogObject struct {
 	Time       int64
 	Additional interface{}
 	Message    interface{}
+	Fields     []Field
 }
 
 // NewLogObject - enhance method to create and return reference of LogObject
@@ -34,6 +163,12 @@ func NewLogObject(level LogLevel, module string, logType LogType, eventTime time
 	}
 }
 
+// WithFields - append the typed fields to the log object and return it
+func (o *LogObject) WithFields(fields ...Field) *LogObject {
+	o.Fields = append(o.Fields, fields...)
+	return o
+}
+
 // LogLevel Object
 type LogLevel int
 
@@ -82,6 +217,21 @@ func StringToLogLevel(level string) LogLevel {
 	return DEBUG
 }
 
+// ParseLogLevel - returns the log level of the name, `warn` is accepted as `warning` too
+func ParseLogLevel(level string) (LogLevel, bool) {
+	switch strings.ToUpper(strings.TrimSpace(level)) {
+	case "DEBUG":
+		return DEBUG, true
+	case "INFO":
+		return INFO, true
+	case "WARNING", "WARN":
+		return WARNING, true
+	case "ERROR":
+		return ERROR, true
+	}
+	return DEBUG, false
+}
+
 // LogType Object
 type LogType struct {
 	name string
@@ -96,6 +246,8 @@ var (
 	FuncMaintenanceType = LogType{name: "FUNC_MAINT"}
 	DebugType           = LogType{name: "DEBUG_INFORMATION"}
 	NilObject           = LogType{name: "NIL_OBJECT"}
+	AppType             = LogType{name: "APP"}
+	SamplingType        = LogType{name: "LOG_SAMPLING"}
 )
 
 func (l LogType) String() string {
@@ -113,5 +265,6 @@ type ZhycanLog struct {
 	Module      string `gorm:"size:1024" json:"module"`
 	Message     string `json:"message"`
 	Additional  string `json:"additional"`
+	Fields      string `json:"fields"`
 	LogTime     int64  `json:"logTime"`
 }
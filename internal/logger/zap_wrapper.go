@@ -2,17 +2,18 @@ package logger
 
 // Imports needed list
 import (
-	"errors"
 	"fmt"
 	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/logger/types"
 	"github.com/abolfazlbeh/zhycan/internal/utils"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 )
 
 // Mark: ZapWrapper
@@ -22,11 +23,18 @@ type ZapWrapper struct {
 	name            string
 	serviceName     string
 	logger          *zap.Logger
-	ch              chan types.LogObject
+	queue           *logQueue
+	sampler         *logSampler
+	drainTimeout    time.Duration
 	initialized     bool
 	wg              sync.WaitGroup
 	operationType   string
 	supportedOutput []string
+	configSource    config.Provider
+	remoteOutputs   []*remoteOutput
+	files           []*rotatingFile
+	sinks           []*sinkOutput
+	*levelController
 }
 
 // Constructor - It initializes the logger configuration params
@@ -35,37 +43,29 @@ func (l *ZapWrapper) Constructor(name string) error {
 	defer l.wg.Done()
 
 	l.name = name
-	l.serviceName = config.GetManager().GetName()
-	l.operationType = config.GetManager().GetOperationType()
-	l.supportedOutput = []string{"console", "file"}
-	l.initialized = false
-
-	channelSize, err := config.GetManager().Get(l.name, "channel_size")
-	if err != nil {
-		return err
+	if l.configSource == nil {
+		l.configSource = config.GetManager()
 	}
+	l.serviceName = l.configSource.GetName()
+	l.operationType = l.configSource.GetOperationType()
+	l.supportedOutput = []string{"console", "file", "graylog", "syslog"}
+	l.initialized = false
 
-	options, err := config.GetManager().Get(l.name, "options")
+	cfg, err := config.DecodeFrom[types.Config](l.configSource, l.name, "")
 	if err != nil {
 		return err
 	}
-
-	var optionArray []string
-	for _, v := range options.([]interface{}) {
-		optionArray = append(optionArray, v.(string))
+	optionArray := cfg.Options
+	outputArray := cfg.Outputs
+
+	l.queue = newLogQueue(cfg)
+	l.drainTimeout = cfg.DrainTimeout
+	if cfg.Sampling.Enabled {
+		l.sampler = newLogSampler(cfg.Sampling, func(obj *types.LogObject) {
+			l.queue.push(*obj)
+		})
 	}
-
-	outputs, err := config.GetManager().Get(l.name, "outputs")
-	if err != nil {
-		return err
-	}
-
-	var outputArray []string
-	for _, v := range outputs.([]interface{}) {
-		outputArray = append(outputArray, v.(string))
-	}
-
-	l.ch = make(chan types.LogObject, int(channelSize.(float64)))
+	l.levelController = newLevelController(cfg.Levels)
 
 	if l.operationType == "prod" {
 		productionEncoderConfig := zap.NewProductionEncoderConfig()
@@ -76,61 +76,55 @@ func (l *ZapWrapper) Constructor(name string) error {
 		for _, outputItem := range outputArray {
 			if utils.ArrayContains(&l.supportedOutput, outputItem) {
 				if outputItem == "console" {
-					level := zapcore.DebugLevel
-
-					key := fmt.Sprintf("%s.level", outputItem)
-					levelStr, err := config.GetManager().Get(l.name, key)
-					if err == nil {
-						level, err = zapcore.ParseLevel(levelStr.(string))
-						if err != nil {
-							continue
-						}
+					outputCfg, err := config.DecodeOptionalFrom[types.OutputConfig](l.configSource, l.name, outputItem)
+					if err != nil {
+						continue
+					}
+
+					level, err := zapcore.ParseLevel(outputCfg.Level)
+					if err != nil {
+						continue
 					}
 
 					consoleEncoder := zapcore.NewConsoleEncoder(productionEncoderConfig)
-					c := zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), level)
+					c := l.outputCore(outputItem, level, zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), zapcore.DebugLevel))
 					cores = append(cores, c)
 				} else if outputItem == "file" {
-					level := zapcore.DebugLevel
-
-					key := fmt.Sprintf("%s.level", outputItem)
-					levelStr, err := config.GetManager().Get(l.name, key)
-					if err == nil {
-						level, err = zapcore.ParseLevel(levelStr.(string))
-						if err != nil {
-							continue
-						}
+					outputCfg, err := config.DecodeOptionalFrom[types.FileConfig](l.configSource, l.name, outputItem)
+					if err != nil {
+						continue
+					}
+
+					level, err := zapcore.ParseLevel(outputCfg.Level)
+					if err != nil {
+						continue
 					}
 
 					// Read the root path of logs
 					path := "logs"
-					key = fmt.Sprintf("%s.path", outputItem)
-					pathStr, err := config.GetManager().Get(l.name, key)
-					if err == nil {
-						if strings.TrimSpace(pathStr.(string)) != "" {
-							path = strings.TrimSpace(pathStr.(string))
-						}
+					if strings.TrimSpace(outputCfg.Path) != "" {
+						path = strings.TrimSpace(outputCfg.Path)
 					}
 
-					// Check the directory existed, If not create all the nested directories
-					if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
-						err1 := os.MkdirAll(path, os.ModePerm)
-						if err1 != nil {
-							continue
-						}
-					}
+					// the directories are created by the first write
+					expectLogPath := filepath.Join(path, fmt.Sprintf("%s.log", l.configSource.GetName()))
+					logFile := newRotatingFile(expectLogPath, outputCfg)
+					l.files = append(l.files, logFile)
+					writer := zapcore.AddSync(logFile)
+					fileEncoder := zapcore.NewJSONEncoder(productionEncoderConfig)
 
-					expectLogPath := filepath.Join(path, fmt.Sprintf("%s.log", config.GetManager().GetName()))
-					logFile, err := os.OpenFile(expectLogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, os.ModePerm)
+					c := l.outputCore(outputItem, level, zapcore.NewCore(fileEncoder, writer, zapcore.DebugLevel))
+					cores = append(cores, c)
+				} else if outputItem == "graylog" || outputItem == "syslog" {
+					c, err := l.remoteOutputCore(outputItem, cfg.ChannelSize)
 					if err != nil {
+						log.Printf("Cannot create log output: %v - %v", outputItem, err)
 						continue
 					}
-					writer := zapcore.AddSync(logFile)
-					fileEncoder := zapcore.NewJSONEncoder(productionEncoderConfig)
-
-					c := zapcore.NewCore(fileEncoder, writer, level)
 					cores = append(cores, c)
 				}
+			} else if !l.openSink(outputItem) {
+				log.Printf("Log output with name `%v` is not supported yet", outputItem)
 			}
 		}
 
@@ -156,62 +150,56 @@ func (l *ZapWrapper) Constructor(name string) error {
 		for _, outputItem := range outputArray {
 			if utils.ArrayContains(&l.supportedOutput, outputItem) {
 				if outputItem == "console" {
-					level := zapcore.DebugLevel
-
-					key := fmt.Sprintf("%s.level", outputItem)
-					levelStr, err := config.GetManager().Get(l.name, key)
-					if err == nil {
-						level, err = zapcore.ParseLevel(levelStr.(string))
-						if err != nil {
-							continue
-						}
+					outputCfg, err := config.DecodeOptionalFrom[types.OutputConfig](l.configSource, l.name, outputItem)
+					if err != nil {
+						continue
+					}
+
+					level, err := zapcore.ParseLevel(outputCfg.Level)
+					if err != nil {
+						continue
 					}
 
 					consoleEncoder := zapcore.NewConsoleEncoder(developmentEncoderConfig)
-					c := zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), level)
+					c := l.outputCore(outputItem, level, zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), zapcore.DebugLevel))
 
 					cores = append(cores, c)
 				} else if outputItem == "file" {
-					level := zapcore.DebugLevel
-
-					key := fmt.Sprintf("%s.level", outputItem)
-					levelStr, err := config.GetManager().Get(l.name, key)
-					if err == nil {
-						level, err = zapcore.ParseLevel(levelStr.(string))
-						if err != nil {
-							continue
-						}
+					outputCfg, err := config.DecodeOptionalFrom[types.FileConfig](l.configSource, l.name, outputItem)
+					if err != nil {
+						continue
+					}
+
+					level, err := zapcore.ParseLevel(outputCfg.Level)
+					if err != nil {
+						continue
 					}
 
 					// Read the root path of logs
 					path := "logs"
-					key = fmt.Sprintf("%s.path", outputItem)
-					pathStr, err := config.GetManager().Get(l.name, key)
-					if err == nil {
-						if strings.TrimSpace(pathStr.(string)) != "" {
-							path = strings.TrimSpace(pathStr.(string))
-						}
+					if strings.TrimSpace(outputCfg.Path) != "" {
+						path = strings.TrimSpace(outputCfg.Path)
 					}
 
-					// Check the directory existed, If not create all the nested directories
-					if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
-						err1 := os.MkdirAll(path, os.ModePerm)
-						if err1 != nil {
-							continue
-						}
-					}
+					// the directories are created by the first write
+					expectLogPath := filepath.Join(path, fmt.Sprintf("%s.log", l.configSource.GetName()))
+					logFile := newRotatingFile(expectLogPath, outputCfg)
+					l.files = append(l.files, logFile)
+					writer := zapcore.AddSync(logFile)
+					fileEncoder := zapcore.NewJSONEncoder(developmentEncoderConfig)
 
-					expectLogPath := filepath.Join(path, fmt.Sprintf("%s.log", config.GetManager().GetName()))
-					logFile, err := os.OpenFile(expectLogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, os.ModePerm)
+					c := l.outputCore(outputItem, level, zapcore.NewCore(fileEncoder, writer, zapcore.DebugLevel))
+					cores = append(cores, c)
+				} else if outputItem == "graylog" || outputItem == "syslog" {
+					c, err := l.remoteOutputCore(outputItem, cfg.ChannelSize)
 					if err != nil {
+						log.Printf("Cannot create log output: %v - %v", outputItem, err)
 						continue
 					}
-					writer := zapcore.AddSync(logFile)
-					fileEncoder := zapcore.NewJSONEncoder(developmentEncoderConfig)
-
-					c := zapcore.NewCore(fileEncoder, writer, level)
 					cores = append(cores, c)
 				}
+			} else if !l.openSink(outputItem) {
+				log.Printf("Log output with name `%v` is not supported yet", outputItem)
 			}
 		}
 
@@ -230,27 +218,100 @@ func (l *ZapWrapper) Constructor(name string) error {
 		}
 	}
 
-	go l.runner()
+	l.queue.start(l.write)
+	if l.sampler != nil {
+		l.sampler.start()
+	}
 	l.initialized = true
 
 	return nil
 }
 
-// Close - it closes logger channel
+// remoteOutputCore - create the core of the `graylog` or `syslog` output
+func (l *ZapWrapper) remoteOutputCore(outputItem string, queueSize int) (zapcore.Core, error) {
+	output, levelStr, err := openRemoteOutput(l.configSource, l.name, outputItem, l.serviceName, queueSize)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := zapcore.ParseLevel(levelStr)
+	if err != nil {
+		output.Close()
+		return nil, err
+	}
+
+	l.remoteOutputs = append(l.remoteOutputs, output)
+	return l.outputCore(outputItem, level, &remoteCore{LevelEnabler: zapcore.DebugLevel, output: output}), nil
+}
+
+// openSink - create the custom output that is registered with the name, it returns false if it is not registered
+func (l *ZapWrapper) openSink(outputItem string) bool {
+	sink, level, ok, err := openSinkOutput(l.configSource, l.name, outputItem, l.serviceName)
+	if !ok {
+		return false
+	}
+	if err != nil {
+		log.Printf("Cannot create log output: %v - %v", outputItem, err)
+		return true
+	}
+
+	l.levelController.register(outputItem, level)
+	l.sinks = append(l.sinks, sink)
+	return true
+}
+
+// outputCore - wrap the core of the output, so its level and the levels of the modules can be changed at runtime
+func (l *ZapWrapper) outputCore(outputItem string, level zapcore.Level, core zapcore.Core) zapcore.Core {
+	l.levelController.register(outputItem, zapToLogLevel(level))
+	return &levelCore{Core: core, output: outputItem, levels: l.levelController}
+}
+
+// Close - it closes logger channel, the queued logs are written before closing the outputs at most in `drain_timeout`
 func (l *ZapWrapper) Close() {
 	l.wg.Wait()
 
+	// the summaries of the sampler are queued before closing
+	if l.sampler != nil {
+		l.sampler.stop()
+	}
+	if !l.queue.close(l.drainTimeout) {
+		log.Printf("The logs are not drained in %v, %v logs are dropped", l.drainTimeout, l.queue.stats().Dropped)
+	}
 	_ = l.logger.Sync()
-	defer close(l.ch)
+	for _, output := range l.remoteOutputs {
+		output.Close()
+	}
+	for _, file := range l.files {
+		_ = file.Close()
+	}
+	for _, sink := range l.sinks {
+		sink.Close()
+	}
 }
 
-// Log - write log object to the channel
+// Log - write log object to the channel, the caller never waits unless the overflow policy is `block`
 func (l *ZapWrapper) Log(obj *types.LogObject) {
 	l.wg.Wait()
 
-	go func(obj *types.LogObject) {
-		l.ch <- *obj
-	}(obj)
+	// resolved secrets of the configs must never reach the outputs
+	obj.Message = config.RedactSecretValue(obj.Message)
+	obj.Additional = config.RedactSecretValue(obj.Additional)
+	obj.Fields = redactFields(obj.Fields)
+
+	if l.sampler != nil && !l.sampler.allow(obj) {
+		return
+	}
+	l.queue.push(*obj)
+}
+
+// Stats - returns the counters of the log channel
+func (l *ZapWrapper) Stats() types.QueueStats {
+	l.wg.Wait()
+	stats := l.queue.stats()
+	if l.sampler != nil {
+		stats.Sampled = l.sampler.Suppressed()
+	}
+	return stats
 }
 
 // IsInitialized - that returns boolean value whether it's initialized
@@ -264,37 +325,42 @@ func (l *ZapWrapper) Instance() *zap.Logger {
 	return l.logger
 }
 
-// Sync - call the sync method of the project
+// Sync - wait for the queued logs and call the sync method of the project
 func (l *ZapWrapper) Sync() {
 	l.wg.Wait()
+	l.queue.wait(l.drainTimeout)
 	l.logger.Sync()
 }
 
-// runner - the goroutine that reads from channel and process it
-func (l *ZapWrapper) runner() {
-	l.wg.Wait()
-	for c := range l.ch {
-		if c.Level.IsLogLevel() {
-			f := []zapcore.Field{
-				zap.Any("service", l.serviceName),
-				zap.Any("module", c.Module),
-				zap.Any("log_type", c.LogType),
-				zap.Any("time", c.Time),
-				zap.Any("additional", c.Additional),
-			}
-			switch c.Level {
-			case types.DEBUG:
-				l.logger.Debug(fmt.Sprintf("%v", c.Message), f...)
-				break
-			case types.INFO:
-				l.logger.Info(fmt.Sprintf("%v", c.Message), f...)
-				break
-			case types.WARNING:
-				l.logger.Warn(fmt.Sprintf("%v", c.Message), f...)
-				break
-			case types.ERROR:
-				l.logger.Error(fmt.Sprintf("%v", c.Message), f...)
-				break
+// write - write the log of the channel to the outputs
+func (l *ZapWrapper) write(c *types.LogObject) {
+	if c.Level.IsLogLevel() {
+		f := []zapcore.Field{
+			zap.Any("service", l.serviceName),
+			zap.Any("module", c.Module),
+			zap.Any("log_type", c.LogType),
+			zap.Any("time", c.Time),
+			zap.Any("additional", c.Additional),
+		}
+		f = append(f, zapFields(c.Fields)...)
+		switch c.Level {
+		case types.DEBUG:
+			l.logger.Debug(fmt.Sprintf("%v", c.Message), f...)
+			break
+		case types.INFO:
+			l.logger.Info(fmt.Sprintf("%v", c.Message), f...)
+			break
+		case types.WARNING:
+			l.logger.Warn(fmt.Sprintf("%v", c.Message), f...)
+			break
+		case types.ERROR:
+			l.logger.Error(fmt.Sprintf("%v", c.Message), f...)
+			break
+		}
+
+		for _, sink := range l.sinks {
+			if l.levelController.enabled(sink.name, c.Level, c.Module) {
+				sink.write(c)
 			}
 		}
 	}
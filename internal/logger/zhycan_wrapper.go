@@ -2,14 +2,13 @@ package logger
 
 // Imports needed list
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/logger/types"
 	"github.com/abolfazlbeh/zhycan/internal/utils"
-	"gorm.io/gorm"
 	"log"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -17,12 +16,16 @@ import (
 )
 
 type OutputOption struct {
-	LevelStr      string         `json:"level"`
-	Level         types.LogLevel `json:"-"`
-	Path          string         `json:"path,omitempty"`
-	l             *log.Logger    `json:"-"`
-	sqlDbInstance *gorm.DB       `json:"-"`
-	dbType        string         `json:"-"`
+	LevelStr string         `json:"level" default:"debug"`
+	Level    types.LogLevel `json:"-"`
+	Path     string         `json:"path,omitempty"`
+	Use      string         `json:"use,omitempty"`
+	Type     string         `json:"type,omitempty" validate:"omitempty,oneof=sql mongo"`
+	l        *log.Logger    `json:"-"`
+	db       *dbOutput      `json:"-"`
+	remote   *remoteOutput  `json:"-"`
+	sink     *sinkOutput    `json:"-"`
+	file     *rotatingFile  `json:"-"`
 }
 
 // MARK: LogMeWrapper
@@ -31,12 +34,17 @@ type OutputOption struct {
 type LogMeWrapper struct {
 	name                  string
 	serviceName           string
-	ch                    chan types.LogObject
+	queue                 *logQueue
+	sampler               *logSampler
+	drainTimeout          time.Duration
+	outputs               []string
 	initialized           bool
 	wg                    sync.WaitGroup
 	operationType         string
 	supportedOutput       []string
 	supportedOutputOption map[string]OutputOption
+	configSource          config.Provider
+	*levelController
 }
 
 // Constructor - It initializes the logger configuration params
@@ -45,104 +53,87 @@ func (l *LogMeWrapper) Constructor(name string) error {
 	defer l.wg.Done()
 
 	l.name = name
-	l.serviceName = config.GetManager().GetName()
-	l.operationType = config.GetManager().GetOperationType()
-	l.supportedOutput = []string{"console", "file", "db"}
-	l.initialized = false
-
-	channelSize, err := config.GetManager().Get(l.name, "channel_size")
-	if err != nil {
-		return err
-	}
-
-	options, err := config.GetManager().Get(l.name, "options")
-	if err != nil {
-		return err
-	}
-
-	optionArray := make([]string, len(options.([]interface{})))
-	for _, v := range options.([]interface{}) {
-		optionArray = append(optionArray, v.(string))
+	if l.configSource == nil {
+		l.configSource = config.GetManager()
 	}
+	l.serviceName = l.configSource.GetName()
+	l.operationType = l.configSource.GetOperationType()
+	l.supportedOutput = []string{"console", "file", "db", "graylog", "syslog"}
+	l.initialized = false
 
-	outputs, err := config.GetManager().Get(l.name, "outputs")
+	cfg, err := config.DecodeFrom[types.Config](l.configSource, l.name, "")
 	if err != nil {
 		return err
 	}
 
-	var outputArray []string
-	for _, v := range outputs.([]interface{}) {
-		outputArray = append(outputArray, v.(string))
+	l.queue = newLogQueue(cfg)
+	l.drainTimeout = cfg.DrainTimeout
+	if cfg.Sampling.Enabled {
+		l.sampler = newLogSampler(cfg.Sampling, func(obj *types.LogObject) {
+			l.queue.push(*obj)
+		})
 	}
-
-	l.ch = make(chan types.LogObject, int(channelSize.(float64)))
+	l.levelController = newLevelController(cfg.Levels)
 
 	//if l.operationType == "prod" {
 	//} else {
 	//}
 	l.supportedOutputOption = make(map[string]OutputOption)
-	for _, item := range outputArray {
+	for _, item := range cfg.Outputs {
 		if utils.ArrayContains(&l.supportedOutput, item) {
-			jsonObj, configReadErr := config.GetManager().Get(l.name, item)
+			r, configReadErr := config.DecodeOptionalFrom[OutputOption](l.configSource, l.name, item)
 			if configReadErr == nil {
-				r := OutputOption{}
-
-				jsonMap := jsonObj.(map[string]interface{})
-				jsonStr, jsonErr := json.Marshal(jsonMap)
-				if jsonErr == nil {
-					jsonErr = json.Unmarshal(jsonStr, &r)
-					if jsonErr == nil {
-						// add it to internal map
-						r.Level = types.StringToLogLevel(r.LevelStr)
-						if item == "console" {
-							r.l = log.New(os.Stdout, "", 0)
-						} else if item == "db" {
-							useDbName := ""
-							dbType := ""
-							if v, ok := jsonMap["use"]; ok {
-								useDbName = v.(string)
-							}
-							if v, ok := jsonMap["type"]; ok {
-								dbType = v.(string)
-							}
-
-							if useDbName != "" && dbType != "" {
-								if dbType == "sql" {
-									r.dbType = dbType
-
-									insDb, err := helpers.GetSqlDbInstance("server1")
-									if err != nil {
-										log.Printf("Cannot Db instance: %v for logger", item)
-									} else {
-										r.sqlDbInstance = insDb
-										if r.sqlDbInstance != nil {
-											err := r.sqlDbInstance.AutoMigrate(&types.ZhycanLog{})
-											if err != nil {
-												log.Printf("Cannot migrate the `ZhycanLog` table")
-											}
-										}
-									}
-								}
-							}
-						}
-						l.supportedOutputOption[item] = r
-
-						// run the instance ...
-						go l.runner(item)
-					} else {
-						log.Printf("Cannot create log instance for: %v - %v", item, jsonErr)
+				// add it to internal map
+				r.Level = types.StringToLogLevel(r.LevelStr)
+				if item == "console" {
+					r.l = log.New(os.Stdout, "", 0)
+				} else if item == "file" {
+					fileCfg, err := config.DecodeOptionalFrom[types.FileConfig](l.configSource, l.name, item)
+					if err != nil {
+						log.Printf("Cannot create log instance for: %v - %v", item, err)
+						continue
 					}
-				} else {
-					log.Printf("Cannot create log instance for: %v - %v", item, jsonErr)
+					r.file = newRotatingFile(filepath.Join(fileCfg.Path, fmt.Sprintf("%s.log", l.serviceName)), fileCfg)
+					r.l = log.New(r.file, "", 0)
+				} else if item == "graylog" || item == "syslog" {
+					output, _, err := openRemoteOutput(l.configSource, l.name, item, l.serviceName, cfg.ChannelSize)
+					if err != nil {
+						log.Printf("Cannot create log instance for: %v - %v", item, err)
+						continue
+					}
+					r.remote = output
+				} else if item == "db" {
+					output, err := l.openDbOutput()
+					if err != nil {
+						log.Printf("Cannot create log instance for: %v - %v", item, err)
+						continue
+					}
+					r.db = output
 				}
+				l.supportedOutputOption[item] = r
+				l.levelController.register(item, r.Level)
+				l.outputs = append(l.outputs, item)
 			} else {
 				log.Printf("Cannot create log instance for: %v - %v", item, configReadErr)
 			}
+		} else if sink, level, ok, err := openSinkOutput(l.configSource, l.name, item, l.serviceName); ok {
+			if err != nil {
+				log.Printf("Cannot create log instance for: %v - %v", item, err)
+				continue
+			}
+			l.supportedOutputOption[item] = OutputOption{LevelStr: level.String(), Level: level, sink: sink}
+			l.levelController.register(item, level)
+			l.outputs = append(l.outputs, item)
 		} else {
 			log.Printf("Log outout with name `%v` is not supported yet", item)
 		}
 	}
 
+	// one goroutine writes every log to all outputs in order
+	l.queue.start(l.write)
+	if l.sampler != nil {
+		l.sampler.start()
+	}
 	l.initialized = true
 
 	return nil
@@ -159,85 +150,157 @@ func (l *LogMeWrapper) Instance() *log.Logger {
 	return l.supportedOutputOption["console"].l
 }
 
-// Log - write log object to the channel
+// Log - write log object to the channel, the caller never waits unless the overflow policy is `block`
 func (l *LogMeWrapper) Log(obj *types.LogObject) {
 	l.wg.Wait()
 
-	go func(obj *types.LogObject) {
-		l.ch <- *obj
-	}(obj)
+	// resolved secrets of the configs must never reach the outputs
+	obj.Message = config.RedactSecretValue(obj.Message)
+	obj.Additional = config.RedactSecretValue(obj.Additional)
+	obj.Fields = redactFields(obj.Fields)
+
+	if l.sampler != nil && !l.sampler.allow(obj) {
+		return
+	}
+	l.queue.push(*obj)
+}
+
+// Stats - returns the counters of the log channel
+func (l *LogMeWrapper) Stats() types.QueueStats {
+	l.wg.Wait()
+	stats := l.queue.stats()
+	if l.sampler != nil {
+		stats.Sampled = l.sampler.Suppressed()
+	}
+	return stats
 }
 
-// Sync - sync all logs to medium
+// Sync - sync all logs to medium, it waits at most `drain_timeout` for the queued logs
 func (l *LogMeWrapper) Sync() {
 	l.wg.Wait()
-	ch := make(chan bool, 1)
-	go func() {
-		for {
-			if len(l.ch) > 0 {
-				time.Sleep(time.Millisecond * 200)
-			} else {
-				ch <- true
-			}
+	l.queue.wait(l.drainTimeout)
+	for _, item := range l.supportedOutputOption {
+		if item.file != nil {
+			_ = item.file.Sync()
 		}
-	}()
-
-	<-ch
+		if item.db != nil {
+			item.db.flush()
+		}
+	}
 }
 
-// Close - it closes logger channel
+// Close - it closes logger channel, the queued logs are written before closing the outputs at most in `drain_timeout`
 func (l *LogMeWrapper) Close() {
 	l.wg.Wait()
-	l.Sync()
-	defer close(l.ch)
+
+	// the summaries of the sampler are queued before closing
+	if l.sampler != nil {
+		l.sampler.stop()
+	}
+	if !l.queue.close(l.drainTimeout) {
+		log.Printf("The logs are not drained in %v, %v logs are dropped", l.drainTimeout, l.queue.stats().Dropped)
+	}
+	for _, item := range l.supportedOutputOption {
+		if item.remote != nil {
+			item.remote.Close()
+		}
+		if item.file != nil {
+			_ = item.file.Close()
+		}
+		if item.db != nil {
+			item.db.Close()
+		}
+		if item.sink != nil {
+			item.sink.Close()
+		}
+	}
 }
 
-// runner - the goroutine that reads from channel and process it
-func (l *LogMeWrapper) runner(output string) {
-	l.wg.Wait()
-	for c := range l.ch {
-		if c.Level <= l.supportedOutputOption[output].Level {
-			if output == "console" {
-				switch c.Level {
-				case types.DEBUG:
-					l.debug(&c, output)
-					break
-				case types.INFO:
-					l.info(&c, output)
-					break
-				case types.WARNING:
-					l.warning(&c, output)
-					break
-				case types.ERROR:
-					l.error(&c, output)
-					break
-				}
-			} else if output == "db" {
-				if l.supportedOutputOption[output].dbType == "sql" {
-					if l.supportedOutputOption[output].sqlDbInstance != nil {
-						item := types.ZhycanLog{
-							Model:       gorm.Model{},
-							ServiceName: l.serviceName,
-							Level:       c.Level.String(),
-							LogType:     c.LogType,
-							Module:      c.Module,
-							Message:     fmt.Sprintf("%v", c.Message),
-							Additional:  fmt.Sprintf("%v", c.Additional),
-							LogTime:     c.Time,
-						}
-						l.supportedOutputOption[output].sqlDbInstance.Create(&item)
-					}
-				}
-			}
+// openDbOutput - create the `db` output on the connection of the `db` module of the config source that its name is in `use`
+func (l *LogMeWrapper) openDbOutput() (*dbOutput, error) {
+	cfg, err := config.DecodeFrom[types.DbOutputConfig](l.configSource, l.name, "db")
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Type == "mongo" {
+		mongoDb, err := helpers.GetMongoDbInstance(l.configSource, cfg.Use)
+		if err != nil {
+			return nil, err
+		}
+		return newDbOutput(l.serviceName, cfg, nil, mongoDb)
+	}
+
+	sqlDb, err := helpers.GetSqlDbInstance(l.configSource, cfg.Use)
+	if err != nil {
+		return nil, err
+	}
+	return newDbOutput(l.serviceName, cfg, sqlDb, nil)
+}
+
+// write - write the log of the channel to all outputs
+func (l *LogMeWrapper) write(c *types.LogObject) {
+	for _, output := range l.outputs {
+		l.writeOutput(output, c)
+	}
+}
+
+// writeOutput - write the log to the output if its level allows it
+func (l *LogMeWrapper) writeOutput(output string, c *types.LogObject) {
+	if !l.levelController.enabled(output, c.Level, c.Module) {
+		return
+	}
+
+	if output == "console" {
+		switch c.Level {
+		case types.DEBUG:
+			l.debug(c, output)
+			break
+		case types.INFO:
+			l.info(c, output)
+			break
+		case types.WARNING:
+			l.warning(c, output)
+			break
+		case types.ERROR:
+			l.error(c, output)
+			break
+		}
+	} else if output == "file" {
+		l.fileLine(c, output)
+	} else if output == "graylog" || output == "syslog" {
+		if l.supportedOutputOption[output].remote != nil {
+			l.supportedOutputOption[output].remote.send(logObjectRecord(l.serviceName, c))
 		}
+	} else if output == "db" {
+		if l.supportedOutputOption[output].db != nil {
+			l.supportedOutputOption[output].db.write(c)
+		}
+	} else if l.supportedOutputOption[output].sink != nil {
+		l.supportedOutputOption[output].sink.write(c)
 	}
 }
 
+// fileLine - write the log to the file without the colors
+func (l *LogMeWrapper) fileLine(object *types.LogObject, output string) {
+	l.supportedOutputOption[output].l.Printf(
+		"%v %v >>> %7v >>> (%v/%v)  - %v ... %v%v\n",
+		l.serviceName,
+		object.Time,
+		object.Level.String(),
+		object.LogType,
+		object.Module,
+		object.Message,
+		object.Additional,
+		fieldsSuffix(object.Fields),
+	)
+}
+
 // debug - log with DEBUG level
 func (l *LogMeWrapper) debug(object *types.LogObject, output string) {
 	if output == "console" {
 		l.supportedOutputOption[output].l.Printf(
-			"\033[37m%v %v >>> %7v >>> (%v/%v)  - %v ... %v\033[0m\n",
+			"\033[37m%v %v >>> %7v >>> (%v/%v)  - %v ... %v%v\033[0m\n",
 			l.serviceName,
 			object.Time,
 			object.Level.String(),
@@ -245,6 +308,7 @@ func (l *LogMeWrapper) debug(object *types.LogObject, output string) {
 			object.Module,
 			object.Message,
 			object.Additional,
+			fieldsSuffix(object.Fields),
 		)
 	}
 }
@@ -253,7 +317,7 @@ func (l *LogMeWrapper) debug(object *types.LogObject, output string) {
 func (l *LogMeWrapper) info(object *types.LogObject, output string) {
 	if output == "console" {
 		l.supportedOutputOption[output].l.Printf(
-			"\033[32m%v %v >>> %7v >>> (%v/%v)  - %v ... %v\033[0m\n",
+			"\033[32m%v %v >>> %7v >>> (%v/%v)  - %v ... %v%v\033[0m\n",
 			l.serviceName,
 			object.Time,
 			object.Level.String(),
@@ -261,6 +325,7 @@ func (l *LogMeWrapper) info(object *types.LogObject, output string) {
 			object.Module,
 			object.Message,
 			object.Additional,
+			fieldsSuffix(object.Fields),
 		)
 	}
 }
@@ -269,7 +334,7 @@ func (l *LogMeWrapper) info(object *types.LogObject, output string) {
 func (l *LogMeWrapper) warning(object *types.LogObject, output string) {
 	if output == "console" {
 		l.supportedOutputOption[output].l.Printf(
-			"\033[33m%v %v >>> %7v >>> (%v/%v)  - %v ... %v\033[0m\n",
+			"\033[33m%v %v >>> %7v >>> (%v/%v)  - %v ... %v%v\033[0m\n",
 			l.serviceName,
 			object.Time,
 			object.Level.String(),
@@ -277,6 +342,7 @@ func (l *LogMeWrapper) warning(object *types.LogObject, output string) {
 			object.Module,
 			object.Message,
 			object.Additional,
+			fieldsSuffix(object.Fields),
 		)
 	}
 }
@@ -285,7 +351,7 @@ func (l *LogMeWrapper) warning(object *types.LogObject, output string) {
 func (l *LogMeWrapper) error(object *types.LogObject, output string) {
 	if output == "console" {
 		l.supportedOutputOption[output].l.Printf(
-			"\033[31m%v %v >>> %7v >>> (%v/%v)  - %v ... %v\033[0m\n",
+			"\033[31m%v %v >>> %7v >>> (%v/%v)  - %v ... %v%v\033[0m\n",
 			l.serviceName,
 			object.Time,
 			object.Level.String(),
@@ -293,6 +359,7 @@ func (l *LogMeWrapper) error(object *types.LogObject, output string) {
 			object.Module,
 			object.Message,
 			object.Additional,
+			fieldsSuffix(object.Fields),
 		)
 	}
 }
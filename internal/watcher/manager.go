@@ -15,6 +15,7 @@ import (
 // Manager object
 type manager struct {
 	name              string
+	configSource      config.Provider
 	watcher           *watcher.Watcher
 	lock              sync.Mutex
 	isInitialized     bool
@@ -23,8 +24,8 @@ type manager struct {
 }
 
 type watchDirStruct struct {
-	Path      string
-	Recursive bool
+	Path      string `json:"path" validate:"required"`
+	Recursive bool   `json:"recursive"`
 }
 
 // MARK: Module variables
@@ -50,57 +51,17 @@ func (m *manager) init() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	// read filter options
-	filterOptions, err := config.GetManager().Get(m.name, "filter_operations")
+	cfg, err := config.DecodeFrom[Config](m.configSource, m.name, "")
 	if err != nil {
+		log.Println(err.Error())
 		return
 	}
+	filterOptionsArray := cfg.FilterOperations
+	filterHooksArray := cfg.FilterHooks
+	watchDirs := cfg.WatchDirs
 
-	var filterOptionsArray []string
-	for _, v := range filterOptions.([]interface{}) {
-		filterOptionsArray = append(filterOptionsArray, v.(string))
-	}
-
-	filterHooks, err := config.GetManager().Get(m.name, "filter_hooks")
-	if err != nil {
-		return
-	}
-
-	var filterHooksArray []string
-	for _, v := range filterHooks.([]interface{}) {
-		filterHooksArray = append(filterHooksArray, v.(string))
-	}
-
-	maxEvent, err := config.GetManager().Get(m.name, "max_event")
-	if err != nil {
-		return
-	}
-
-	printWatchedFiles, err := config.GetManager().Get(m.name, "print_watched_files")
-	if err != nil {
-		return
-	}
-	m.printWatchedFiles = printWatchedFiles.(bool)
-
-	watchInterval, err := config.GetManager().Get(m.name, "watch_interval")
-	if err != nil {
-		return
-	}
-	m.watchInterval = watchInterval.(int)
-
-	var watchDirs []watchDirStruct
-	watchDirsObj, err := config.GetManager().Get(m.name, "watch_dirs")
-	if err != nil {
-		return
-	}
-
-	for _, v := range watchDirsObj.([]interface{}) {
-		item := v.(map[string]interface{})
-		watchDirs = append(watchDirs, watchDirStruct{
-			Path:      item["path"].(string),
-			Recursive: item["recursive"].(bool),
-		})
-	}
+	m.printWatchedFiles = cfg.PrintWatchedFiles
+	m.watchInterval = cfg.WatchInterval
 
 	// Let's config a watcher
 	m.watcher = watcher.New()
@@ -133,7 +94,7 @@ func (m *manager) init() {
 			regexp.MustCompile(item), false))
 	}
 
-	m.watcher.SetMaxEvents(maxEvent.(int))
+	m.watcher.SetMaxEvents(cfg.MaxEvent)
 
 	for _, item := range watchDirs {
 		if item.Recursive {
@@ -156,7 +117,7 @@ func (m *manager) restartOnChangeConfig() {
 	defer m.lock.Unlock()
 
 	// Config config server to reload
-	wrapper, err := config.GetManager().GetConfigWrapper(m.name)
+	wrapper, err := m.configSource.GetConfigWrapper(m.name)
 	if err == nil {
 		wrapper.RegisterChangeCallback(func() interface{} {
 			m.Stop()
@@ -171,13 +132,19 @@ func (m *manager) restartOnChangeConfig() {
 
 // MARK: Public Functions
 
-// GetManager - This function returns singleton instance of Logger Manager
+// NewManager - create an independent Watcher Manager that reads its config from the source
+func NewManager(source config.Provider) *manager {
+	m := &manager{configSource: source}
+	m.init()
+	m.restartOnChangeConfig()
+	return m
+}
+
+// GetManager - This function returns singleton instance of Watcher Manager
 func GetManager() *manager {
 	// once used for prevent race condition and manage critical section.
 	once.Do(func() {
-		managerInstance = &manager{}
-		managerInstance.init()
-		managerInstance.restartOnChangeConfig()
+		managerInstance = NewManager(config.GetManager())
 	})
 	return managerInstance
 }
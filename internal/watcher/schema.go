@@ -0,0 +1,11 @@
+package watcher
+
+// Imports needed list
+import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
+)
+
+// Schema - returns the JSON Schema of the `watcher` config module
+func Schema() *config.Schema {
+	return config.ModuleSchema("watcher", config.SchemaOf(Config{}))
+}
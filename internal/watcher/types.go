@@ -0,0 +1,11 @@
+package watcher
+
+// Config - the structure of the `watcher` config module
+type Config struct {
+	FilterOperations  []string         `json:"filter_operations" validate:"dive,oneof=create move rename remove write"`
+	FilterHooks       []string         `json:"filter_hooks"`
+	WatchDirs         []watchDirStruct `json:"watch_dirs" validate:"required,dive"`
+	MaxEvent          int              `json:"max_event" default:"1" validate:"min=0"`
+	PrintWatchedFiles bool             `json:"print_watched_files"`
+	WatchInterval     int              `json:"watch_interval" default:"100" validate:"min=1"`
+}
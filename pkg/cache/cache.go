@@ -3,9 +3,26 @@ package cache
 import (
 	"context"
 	"github.com/abolfazlbeh/zhycan/internal/cache"
+	"github.com/abolfazlbeh/zhycan/internal/config"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
 	"time"
 )
 
+// Cache - the cache instance of the manager
+type Cache = cache.ICache
+
+// Manager - the cache instances of a config source
+type Manager interface {
+	GetCache(cacheName string) (Cache, error)
+	Release() error
+}
+
+// NewManager - create an independent cache Manager that reads its config from the source and logs through the logger,
+// the nil logger is the logger of `logger.json`
+func NewManager(source config.Provider, l types.Logger) Manager {
+	return cache.NewManagerWithLogger(source, l)
+}
+
 // SetIntoCache - set simple type value into cache by key
 func SetIntoCache(ctx context.Context, cacheInstanceName string, key string, val any, expiration time.Duration) error {
 	cacheInstance, err := cache.GetManager().GetCache(cacheInstanceName)
@@ -5,6 +5,37 @@ import (
 	"time"
 )
 
+// SecretProvider - the interface of the secret backends that resolve `${secret:<name>:<ref>}` values
+type SecretProvider = config.SecretProvider
+
+// SecretProviderFunc - an adapter to use an ordinary function as a SecretProvider
+type SecretProviderFunc = config.SecretProviderFunc
+
+// ChangeEvent - the changed, added and removed key paths of a module with their old and new values
+type ChangeEvent = config.ChangeEvent
+
+// Tx - the transaction that stages the changes of a module to write them at once
+type Tx = config.Tx
+
+// Snapshot - one saved version of the config file of a module
+type Snapshot = config.Snapshot
+
+// Options - the params of an independent config manager that is created by New
+type Options = config.Options
+
+// Provider - the source of the configs that can be passed to the subsystem managers
+type Provider = config.Provider
+
+// New - create an independent config manager with its own config tree, the default manager is not changed
+func New(opts Options) (Provider, error) {
+	return config.New(opts)
+}
+
+// FromMap - create an independent config manager that its modules are built from the maps, see config.FromMap
+func FromMap(modules map[string]map[string]interface{}) (Provider, error) {
+	return config.FromMap(modules)
+}
+
 // InitializeManager - Create a new config manager instance and wait to initialize it
 func InitializeManager(configBasePath string, configInitialMode string, configEnvPrefix string) error {
 	err := config.CreateManager(configBasePath, configInitialMode, configEnvPrefix)
@@ -60,6 +91,56 @@ func Set(category string, name string, value interface{}) error {
 	return config.GetManager().Set(category, name, value)
 }
 
+// Update - apply all changes of the function to the category at once by writing a temp file and renaming it.
+// If the function returns an error nothing is written, and the subscribers are notified once per update.
+func Update(category string, fn func(tx *Tx) error) error {
+	return config.GetManager().Update(category, fn)
+}
+
+// Rollback - restore the config file of the category to the snapshot version
+func Rollback(category string, version int) error {
+	return config.GetManager().Rollback(category, version)
+}
+
+// Snapshots - returns the last saved versions of the config file of the category
+func Snapshots(category string) ([]Snapshot, error) {
+	return config.GetManager().Snapshots(category)
+}
+
+// Unmarshal - decode the value of the key in specific category into the out object,
+// apply the `default` tags and validate it by the `validate` tags
+func Unmarshal(category string, name string, out interface{}) error {
+	return config.GetManager().Unmarshal(category, name, out)
+}
+
+// Decode - decode the value of the key in specific category into a new object of type T
+func Decode[T any](category string, name string) (T, error) {
+	return config.Decode[T](category, name)
+}
+
+// GetKeySource - returns the layer and the file that the value of the key in the category came from
+func GetKeySource(category string, name string) (config.KeySource, error) {
+	return config.GetManager().GetKeySource(category, name)
+}
+
+// GetEnvOverrides - returns the env variables that override the keys of the category, e.g. `ZHYCAN_DB_SERVER1_HOST`.
+// The sensitive values are redacted.
+func GetEnvOverrides(category string) ([]config.EnvOverride, error) {
+	return config.GetManager().GetEnvOverrides(category)
+}
+
+// Introspect - returns the mode, the status of the modules and their effective config with the source of the keys.
+// The passwords, tokens and secrets are redacted.
+func Introspect() config.Introspection {
+	return config.GetManager().Introspect()
+}
+
+// OnChange - call the listener with the changes of the key path (and the keys under it) in the category.
+// Bursty file events are debounced, so the listener is called once for them.
+func OnChange(category string, keyPath string, listener func(event ChangeEvent)) error {
+	return config.GetManager().Subscribe(category, keyPath, listener)
+}
+
 // IsInitialized - iterate over all config wrappers and see all initialised correctly
 func IsInitialized() bool {
 	return config.GetManager().IsInitialized()
@@ -74,3 +155,14 @@ func GetAllInitializedModuleList() []string {
 func ManualLoadConfig(configBasePath string, configName string) error {
 	return config.GetManager().ManualLoadConfig(configBasePath, configName)
 }
+
+// StopLoader - stop the loader that refreshes `remote` modules from the config server
+func StopLoader() {
+	config.GetManager().StopLoader()
+}
+
+// RegisterSecretProvider - register a secret provider, e.g. a vault client as `kv` to resolve `${secret:kv:path#key}`.
+// It must be called before InitializeManager so the references are resolved while the configs are loaded.
+func RegisterSecretProvider(name string, provider SecretProvider) error {
+	return config.RegisterSecretProvider(name, provider)
+}
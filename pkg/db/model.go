@@ -1,12 +1,29 @@
 package db
 
 import (
+	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/db"
 	"github.com/abolfazlbeh/zhycan/internal/logger"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
 	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
 )
 
+// Manager - the connections of the databases of a config source
+type Manager interface {
+	GetDb(instanceName string) (*gorm.DB, error)
+	GetMongoDb(instanceName string) (*mongo.Database, error)
+	Migrate(instanceName string, models ...interface{}) error
+	AttachMigrationFunc(instanceName string, f func(migrator gorm.Migrator) error) error
+	RegisterLogger(l types.Logger)
+}
+
+// NewManager - create an independent db Manager that reads its config from the source and logs the queries through
+// the logger, the nil logger is the logger of `logger.json`
+func NewManager(source config.Provider, l types.Logger) Manager {
+	return db.NewManagerWithLogger(source, l)
+}
+
 // GetDb - Get *gorm.DB instance from the underlying interfaces
 func GetDb(instanceName string) (*gorm.DB, error) {
 	return db.GetManager().GetDb(instanceName)
@@ -2,7 +2,9 @@ package http
 
 import (
 	"fmt"
+	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/http"
+	"github.com/abolfazlbeh/zhycan/internal/logger/types"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,6 +22,22 @@ const (
 	methodUse     = "USE"
 )
 
+// Manager - the http servers of a config source
+type Manager interface {
+	AddRoute(method string, path string, f func(c *gin.Context), routeName string, versions []string, groupNames []string, serverName ...string) error
+	AddGroup(groupName string, f func(c *gin.Context), groupsName []string, serverName ...string) error
+	AttachErrorHandler(f func(ctx *gin.Context, err any), serverNames ...string) error
+	GetAllRoutes() []gin.RouteInfo
+	StartServers() error
+	StopServers() error
+}
+
+// NewManager - create an independent http Manager that reads its config from the source and its servers log through
+// the logger, the nil logger is the logger of `logger.json`
+func NewManager(source config.Provider, l types.Logger) Manager {
+	return http.NewManagerWithLogger(source, l)
+}
+
 // HttpRoute - Structure of the route
 type HttpRoute struct {
 	Method     string
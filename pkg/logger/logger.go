@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"context"
+	"github.com/abolfazlbeh/zhycan/internal/config"
 	"github.com/abolfazlbeh/zhycan/internal/logger"
 	"github.com/abolfazlbeh/zhycan/internal/logger/types"
 	"time"
@@ -11,6 +13,33 @@ type LogObject types.LogObject
 type LogLevel types.LogLevel
 type LogType types.LogType
 
+// Logger - the logger that can be passed to the managers of the other subsystems, e.g. `db.NewManager(source, l)`
+type Logger = types.Logger
+
+// Levels - the levels of the outputs and the modules, e.g. `Levels{Modules: map[string]string{"db": "debug"}}`
+type Levels = types.Levels
+
+// QueueStats - the counters of the log channel
+type QueueStats = types.QueueStats
+
+// Sink - the custom output, e.g. kafka, a webhook or an in-memory ring, that is called with the log and its encoded form
+type Sink = types.Sink
+
+// SinkFactory - create the custom output by its options, it is registered by `RegisterSink`
+type SinkFactory = types.SinkFactory
+
+// SinkOptions - the options of the custom output, its own config block is read by `Decode`
+type SinkOptions = types.SinkOptions
+
+// CaptureT - the part of `*testing.T` that the capture logger needs
+type CaptureT interface {
+	logger.TestingT
+	Cleanup(func())
+}
+
+// Field - a typed key/value pair of the log, e.g. `logger.String("user", id)`
+type Field = types.Field
+
 // Some Constants - used with LogLevel
 const (
 	DEBUG   LogLevel = LogLevel(types.DEBUG)
@@ -22,6 +51,7 @@ const (
 var (
 	FuncMaintenanceType LogType = LogType(types.NewLogType(types.FuncMaintenanceType.String()))
 	DebugType           LogType = LogType(types.NewLogType(types.DebugType.String()))
+	AppType             LogType = LogType(types.NewLogType(types.AppType.String()))
 )
 
 // NewLogObject - enhance method to create and return reference of LogObject
@@ -36,9 +66,41 @@ func NewLogObject(level LogLevel, module string, logType LogType, eventTime time
 	}
 }
 
+// MARK: Manager
+
+// Manager - the logger of a config source, it is independent of the default logger of `logger.json`
+type Manager struct {
+	manager interface {
+		GetLogger() (types.Logger, *logger.Error)
+		Levels() (types.Levels, *logger.Error)
+		SetLevels(levels types.Levels) *logger.Error
+		Stats() (types.QueueStats, *logger.Error)
+	}
+}
+
+// NewManager - create an independent logger that reads its config from the source, e.g. `config.FromMap(...)`
+func NewManager(source config.Provider) *Manager {
+	return &Manager{manager: logger.NewManager(source)}
+}
+
+// defaultManager - returns the Manager of the default logger
+func defaultManager() *Manager {
+	return &Manager{manager: logger.GetManager()}
+}
+
+// GetLogger - returns the logger to pass it to the managers of the other subsystems
+func (m *Manager) GetLogger() (Logger, *LogError) {
+	l, err := m.manager.GetLogger()
+	if err != nil {
+		p := LogError(*err)
+		return nil, &p
+	}
+	return l, nil
+}
+
 // Log - write log object to the channel
-func Log(object *LogObject) *LogError {
-	l, err := logger.GetManager().GetLogger()
+func (m *Manager) Log(object *LogObject) *LogError {
+	l, err := m.manager.GetLogger()
 	if err == nil {
 		if l.IsInitialized() {
 			p := types.LogObject(*object)
@@ -52,8 +114,8 @@ func Log(object *LogObject) *LogError {
 }
 
 // Sync - sync all logs to medium
-func Sync() *LogError {
-	l, err := logger.GetManager().GetLogger()
+func (m *Manager) Sync() *LogError {
+	l, err := m.manager.GetLogger()
 	if err == nil {
 		if l.IsInitialized() {
 			l.Sync()
@@ -66,8 +128,8 @@ func Sync() *LogError {
 }
 
 // Close - it closes logger channel
-func Close() *LogError {
-	l, err := logger.GetManager().GetLogger()
+func (m *Manager) Close() *LogError {
+	l, err := m.manager.GetLogger()
 	if err == nil {
 		if l.IsInitialized() {
 			l.Close()
@@ -78,3 +140,298 @@ func Close() *LogError {
 	p := LogError(*err)
 	return &p
 }
+
+// GetLevels - returns the current levels of the outputs and the modules
+func (m *Manager) GetLevels() (Levels, *LogError) {
+	levels, err := m.manager.Levels()
+	if err != nil {
+		p := LogError(*err)
+		return levels, &p
+	}
+	return levels, nil
+}
+
+// SetLevels - change the levels of the outputs and the modules without restart until the next reload of the config,
+// the empty level or `default` removes the level of the module
+func (m *Manager) SetLevels(levels Levels) *LogError {
+	err := m.manager.SetLevels(levels)
+	if err != nil {
+		p := LogError(*err)
+		return &p
+	}
+	return nil
+}
+
+// Stats - returns the counters of the log channel, e.g. the number of the logs that are dropped by the overflow policy
+func (m *Manager) Stats() (QueueStats, *LogError) {
+	stats, err := m.manager.Stats()
+	if err != nil {
+		p := LogError(*err)
+		return stats, &p
+	}
+	return stats, nil
+}
+
+// MARK: Default Logger
+
+// Log - write log object to the channel
+func Log(object *LogObject) *LogError {
+	return defaultManager().Log(object)
+}
+
+// Sync - sync all logs to medium
+func Sync() *LogError {
+	return defaultManager().Sync()
+}
+
+// Close - it closes logger channel
+func Close() *LogError {
+	return defaultManager().Close()
+}
+
+// GetLevels - returns the current levels of the outputs and the modules
+func GetLevels() (Levels, *LogError) {
+	return defaultManager().GetLevels()
+}
+
+// SetLevels - change the levels of the outputs and the modules without restart until the next reload of the config,
+// the empty level or `default` removes the level of the module
+func SetLevels(levels Levels) *LogError {
+	return defaultManager().SetLevels(levels)
+}
+
+// Stats - returns the counters of the log channel, e.g. the number of the logs that are dropped by the overflow policy
+func Stats() (QueueStats, *LogError) {
+	return defaultManager().Stats()
+}
+
+// RegisterSink - register the custom output with the name, it is used when the name is in the `outputs` of `logger.json`
+// and its block (`level`, `encoder` and its own keys) has the same name. It must be registered before the logger is created.
+func RegisterSink(name string, factory SinkFactory) *LogError {
+	err := logger.RegisterSink(name, factory)
+	if err != nil {
+		p := LogError(*err)
+		return &p
+	}
+	return nil
+}
+
+// NewCaptureLogger - create a capture logger that is not installed, e.g. to pass it to `db.RegisterLogger`
+func NewCaptureLogger() *CaptureLogger {
+	return &CaptureLogger{CaptureLogger: logger.NewCaptureLogger()}
+}
+
+// Capture - install a capture logger in place of the logger until the end of the test and return it
+func Capture(t CaptureT) *CaptureLogger {
+	t.Helper()
+	capture := NewCaptureLogger()
+	restore := logger.GetManager().ReplaceLogger(capture.CaptureLogger)
+	t.Cleanup(restore)
+	return capture
+}
+
+// MARK: CaptureLogger
+
+// CaptureLogger - the logger of the tests that keeps the logs in memory, e.g. `capture.AssertLogged(t, logger.ERROR, "db", "failed")`
+type CaptureLogger struct {
+	*logger.CaptureLogger
+}
+
+// Entries - returns a copy of the recorded logs in order
+func (c *CaptureLogger) Entries() []LogObject {
+	return toLogObjects(c.CaptureLogger.Entries())
+}
+
+// Find - returns the recorded logs that the function matches
+func (c *CaptureLogger) Find(match func(obj LogObject) bool) []LogObject {
+	return toLogObjects(c.CaptureLogger.Find(func(obj types.LogObject) bool {
+		return match(LogObject(obj))
+	}))
+}
+
+// Logged - returns the recorded logs with the level and the module that their message contains the text
+func (c *CaptureLogger) Logged(level LogLevel, module string, substr string) []LogObject {
+	return toLogObjects(c.CaptureLogger.Logged(types.LogLevel(level), module, substr))
+}
+
+// LoggedWithType - returns the recorded logs of the log type that their message contains the text
+func (c *CaptureLogger) LoggedWithType(logType LogType, substr string) []LogObject {
+	return toLogObjects(c.CaptureLogger.LoggedWithType(types.LogType(logType), substr))
+}
+
+// LoggedWithField - returns the recorded logs of the module that have the field with the value
+func (c *CaptureLogger) LoggedWithField(module string, key string, value interface{}) []LogObject {
+	return toLogObjects(c.CaptureLogger.LoggedWithField(module, key, value))
+}
+
+// AssertLogged - report an error if no log with the level and the module contains the text
+func (c *CaptureLogger) AssertLogged(t logger.TestingT, level LogLevel, module string, substr string) bool {
+	t.Helper()
+	return c.CaptureLogger.AssertLogged(t, types.LogLevel(level), module, substr)
+}
+
+// AssertNotLogged - report an error if a log with the level and the module contains the text
+func (c *CaptureLogger) AssertNotLogged(t logger.TestingT, level LogLevel, module string, substr string) bool {
+	t.Helper()
+	return c.CaptureLogger.AssertNotLogged(t, types.LogLevel(level), module, substr)
+}
+
+// AssertLoggedWithType - report an error if no log of the log type contains the text
+func (c *CaptureLogger) AssertLoggedWithType(t logger.TestingT, logType LogType, substr string) bool {
+	t.Helper()
+	return c.CaptureLogger.AssertLoggedWithType(t, types.LogType(logType), substr)
+}
+
+// toLogObjects - convert the recorded logs to the public type
+func toLogObjects(items []types.LogObject) []LogObject {
+	result := make([]LogObject, len(items))
+	for i, item := range items {
+		result[i] = LogObject(item)
+	}
+	return result
+}
+
+// MARK: Fields
+
+// String - create a field with the string value
+func String(key string, value string) Field {
+	return types.String(key, value)
+}
+
+// Int - create a field with the int value
+func Int(key string, value int) Field {
+	return types.Int(key, value)
+}
+
+// Int64 - create a field with the int64 value
+func Int64(key string, value int64) Field {
+	return types.Int64(key, value)
+}
+
+// Float64 - create a field with the float64 value
+func Float64(key string, value float64) Field {
+	return types.Float64(key, value)
+}
+
+// Bool - create a field with the boolean value
+func Bool(key string, value bool) Field {
+	return types.Bool(key, value)
+}
+
+// Duration - create a field with the duration value
+func Duration(key string, value time.Duration) Field {
+	return types.Duration(key, value)
+}
+
+// Time - create a field with the time value
+func Time(key string, value time.Time) Field {
+	return types.Time(key, value)
+}
+
+// Err - create the `error` field
+func Err(err error) Field {
+	return types.Err(err)
+}
+
+// Any - create a field with any value
+func Any(key string, value interface{}) Field {
+	return types.Any(key, value)
+}
+
+// MARK: Structured Logging
+
+// logWithFields - log the message with the typed fields and the fields of the context by the `APP` log type
+func logWithFields(ctx context.Context, level LogLevel, module string, msg string, fields []Field) *LogError {
+	object := NewLogObject(level, module, AppType, time.Now(), msg, nil)
+	object.Fields = fields
+	// the fields of the context (e.g. the request id) are attached to every log
+	p := (*types.LogObject)(object).WithContext(ctx)
+	return Log((*LogObject)(p))
+}
+
+// Debug - log the message with the typed fields in DEBUG level,
+// e.g. `logger.Debug(ctx, "users", "user created", logger.String("user", id))`
+func Debug(ctx context.Context, module string, msg string, fields ...Field) *LogError {
+	return logWithFields(ctx, DEBUG, module, msg, fields)
+}
+
+// Info - log the message with the typed fields in INFO level
+func Info(ctx context.Context, module string, msg string, fields ...Field) *LogError {
+	return logWithFields(ctx, INFO, module, msg, fields)
+}
+
+// Warning - log the message with the typed fields in WARNING level
+func Warning(ctx context.Context, module string, msg string, fields ...Field) *LogError {
+	return logWithFields(ctx, WARNING, module, msg, fields)
+}
+
+// Error - log the message with the typed fields in ERROR level
+func Error(ctx context.Context, module string, msg string, fields ...Field) *LogError {
+	return logWithFields(ctx, ERROR, module, msg, fields)
+}
+
+// MARK: Context
+
+// ContextLogger - the logger that attaches the fields of the context to every log
+type ContextLogger struct {
+	ctx    context.Context
+	fields []Field
+}
+
+// FromContext - returns the logger of the context, e.g. `logger.FromContext(c.Request.Context()).Info("users", "created")`
+func FromContext(ctx context.Context) *ContextLogger {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &ContextLogger{ctx: ctx}
+}
+
+// With - returns a copy of the logger that attaches the fields too
+func (l *ContextLogger) With(fields ...Field) *ContextLogger {
+	return &ContextLogger{ctx: l.ctx, fields: append(append([]Field{}, l.fields...), fields...)}
+}
+
+// Debug - log the message in DEBUG level
+func (l *ContextLogger) Debug(module string, msg string, fields ...Field) *LogError {
+	return logWithFields(l.ctx, DEBUG, module, msg, append(append([]Field{}, l.fields...), fields...))
+}
+
+// Info - log the message in INFO level
+func (l *ContextLogger) Info(module string, msg string, fields ...Field) *LogError {
+	return logWithFields(l.ctx, INFO, module, msg, append(append([]Field{}, l.fields...), fields...))
+}
+
+// Warning - log the message in WARNING level
+func (l *ContextLogger) Warning(module string, msg string, fields ...Field) *LogError {
+	return logWithFields(l.ctx, WARNING, module, msg, append(append([]Field{}, l.fields...), fields...))
+}
+
+// Error - log the message in ERROR level
+func (l *ContextLogger) Error(module string, msg string, fields ...Field) *LogError {
+	return logWithFields(l.ctx, ERROR, module, msg, append(append([]Field{}, l.fields...), fields...))
+}
+
+// ContextWithFields - returns a copy of the context that carries the fields for all logs of it
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	return types.ContextWithFields(ctx, fields...)
+}
+
+// WithRequestID - returns a copy of the context that carries the request id
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return types.WithRequestID(ctx, requestID)
+}
+
+// WithTrace - returns a copy of the context that carries the trace id and the span id
+func WithTrace(ctx context.Context, traceID string, spanID string) context.Context {
+	return types.WithTrace(ctx, traceID, spanID)
+}
+
+// WithUser - returns a copy of the context that carries the user
+func WithUser(ctx context.Context, user string) context.Context {
+	return types.WithUser(ctx, user)
+}
+
+// RequestID - returns the request id that the context carries
+func RequestID(ctx context.Context) string {
+	return types.ContextValue(ctx, types.RequestIDKey)
+}